@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*AlarmCollector)(nil)
+
+// alarmLevels are the VergeOS alarm levels, each of which gets a count on
+// every scrape so that alerts on a level don't depend on the series existing.
+var alarmLevels = []string{"critical", "error", "warning", "message"}
+
+// AlarmCollector collects the active VergeOS alarms
+type AlarmCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	alarmActive *prometheus.Desc
+	alarmsTotal *prometheus.Desc
+}
+
+// NewAlarmCollector creates a new AlarmCollector
+func NewAlarmCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *AlarmCollector {
+	return &AlarmCollector{
+		BaseCollector: *NewBaseCollector("alarm", client, scrapeTimeout, opts...),
+		alarmActive: prometheus.NewDesc(
+			"vergeos_alarm_active",
+			"Number of active alarms of this level and type raised on the owner (normally 1)",
+			[]string{"system_name", "level", "owner_type", "owner", "type"},
+			nil,
+		),
+		alarmsTotal: prometheus.NewDesc(
+			"vergeos_alarms_total",
+			"Number of active alarms by level",
+			[]string{"system_name", "level"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (ac *AlarmCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ac.alarmActive
+	ch <- ac.alarmsTotal
+
+	ac.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (ac *AlarmCollector) Collect(ch chan<- prometheus.Metric) {
+	ac.mutex.Lock()
+	defer ac.mutex.Unlock()
+
+	ac.Run(ch, ac.collect)
+}
+
+// alarmKey identifies the alarms sharing one vergeos_alarm_active series.
+type alarmKey struct {
+	level, ownerType, owner, alarmType string
+}
+
+func (ac *AlarmCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := ac.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	alarms, err := ac.Client().Alarms.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching alarms: %w", err)
+	}
+
+	// Alarms with identical labels (the same type raised twice on one owner)
+	// are summed into one series.
+	active := make(map[alarmKey]int)
+	levels := make(map[string]int, len(alarmLevels))
+	for _, level := range alarmLevels {
+		levels[level] = 0
+	}
+	for _, alarm := range alarms {
+		active[alarmKey{alarm.Level, alarm.OwnerType, alarm.OwnerName, alarm.Type}]++
+		levels[alarm.Level]++
+	}
+
+	for key, n := range active {
+		ch <- prometheus.MustNewConstMetric(ac.alarmActive, prometheus.GaugeValue, float64(n),
+			systemName, key.level, key.ownerType, key.owner, key.alarmType)
+	}
+	for level, n := range levels {
+		ch <- prometheus.MustNewConstMetric(ac.alarmsTotal, prometheus.GaugeValue, float64(n), systemName, level)
+	}
+
+	return nil
+}
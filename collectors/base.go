@@ -3,14 +3,24 @@ package collectors
 import (
 	"context"
 	"fmt"
+	"log"
+	"regexp"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	vergeos "github.com/verge-io/govergeos"
 )
 
 // BaseCollector provides common functionality for all collectors
 type BaseCollector struct {
+	// Collector name, reported in the collector label of the scrape metrics
+	name string
+
+	// Self-instrumentation emitted by Run
+	scrapeDuration *prometheus.Desc
+	scrapeSuccess  *prometheus.Desc
+
 	// SDK client for API operations
 	client *vergeos.Client
 
@@ -20,15 +30,144 @@ type BaseCollector struct {
 	// Cached system name
 	systemName string
 
+	// Restricts which named objects (VMs, tenants, nodes, networks) are reported
+	filter NameFilter
+
+	// Labels carried by the collector's info metric, nil for its default set
+	infoLabels []string
+
+	// Error returned by the most recent Run, nil on success
+	lastErr error
+
+	// Shares API responses with the client's other collectors during a scrape
+	cache *APICache
+
+	// Bounds concurrent per-object API requests (see FanOut)
+	limiter *Limiter
+
 	mutex sync.Mutex
 }
 
-// NewBaseCollector creates a new BaseCollector with SDK client and scrape timeout
-func NewBaseCollector(client *vergeos.Client, scrapeTimeout time.Duration) *BaseCollector {
-	return &BaseCollector{
+// Option configures optional BaseCollector behaviour at construction time.
+type Option func(*BaseCollector)
+
+// NameFilter selects objects by name. A nil Include matches every name, and
+// Exclude takes precedence over Include.
+type NameFilter struct {
+	Include *regexp.Regexp
+	Exclude *regexp.Regexp
+}
+
+// Match reports whether name passes the filter.
+func (f NameFilter) Match(name string) bool {
+	if f.Exclude != nil && f.Exclude.MatchString(name) {
+		return false
+	}
+	return f.Include == nil || f.Include.MatchString(name)
+}
+
+// WithNameFilter limits a collector to objects whose names pass f. Collectors
+// that report per-object metrics (node, network, tenant, vm, vnet) honour it;
+// the others ignore it.
+func WithNameFilter(f NameFilter) Option {
+	return func(bc *BaseCollector) {
+		bc.filter = f
+	}
+}
+
+// WithInfoLabels sets the labels a collector's info metric carries, from
+// those it supports. Collectors with an info metric (vm) honour it; the
+// others ignore it.
+func WithInfoLabels(labels []string) Option {
+	return func(bc *BaseCollector) {
+		bc.infoLabels = labels
+	}
+}
+
+// WithAPICache shares the responses of tables several collectors read through
+// c. Collectors built on the same client should share one APICache.
+func WithAPICache(c *APICache) Option {
+	return func(bc *BaseCollector) {
+		bc.cache = c
+	}
+}
+
+// NewBaseCollector creates a new BaseCollector with collector name, SDK client and scrape timeout
+func NewBaseCollector(name string, client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *BaseCollector {
+	constLabels := prometheus.Labels{"collector": name}
+	bc := &BaseCollector{
+		name:          name,
 		client:        client,
 		scrapeTimeout: scrapeTimeout,
+		scrapeDuration: prometheus.NewDesc(
+			"vergeos_exporter_collector_duration_seconds",
+			"Time the collector took to scrape the VergeOS API",
+			nil, constLabels,
+		),
+		scrapeSuccess: prometheus.NewDesc(
+			"vergeos_exporter_collector_success",
+			"Whether the collector completed its scrape (1=success, 0=failed)",
+			nil, constLabels,
+		),
+	}
+	for _, opt := range opts {
+		opt(bc)
+	}
+	if bc.limiter == nil {
+		bc.limiter = NewLimiter(DefaultConcurrency)
+	}
+	return bc
+}
+
+// DescribeScrape sends the descriptors of the metrics emitted by Run.
+func (bc *BaseCollector) DescribeScrape(ch chan<- *prometheus.Desc) {
+	ch <- bc.scrapeDuration
+	ch <- bc.scrapeSuccess
+}
+
+// Run calls collect with a scrape-timeout context, then emits the collector's
+// duration and success metrics. A non-nil error from collect is logged and
+// reported as vergeos_exporter_collector_success 0, so a scrape that lost
+// series says why.
+//
+// collect should return an error when a failure leaves the scrape without
+// the collector's core series, and log and skip failures that only drop
+// optional extras. Independent sections can each return their error and be
+// combined with errors.Join, so a failed one doesn't stop the others but
+// still marks the scrape as failed.
+func (bc *BaseCollector) Run(ch chan<- prometheus.Metric, collect func(ctx context.Context, ch chan<- prometheus.Metric) error) {
+	ctx, cancel := bc.ScrapeContext()
+	defer cancel()
+
+	start := time.Now()
+	err := collect(ctx, ch)
+	duration := time.Since(start).Seconds()
+
+	success := 1.0
+	if err != nil {
+		log.Printf("%s collector failed: %v", bc.name, err)
+		success = 0
 	}
+	bc.mutex.Lock()
+	bc.lastErr = err
+	bc.mutex.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(bc.scrapeDuration, prometheus.GaugeValue, duration)
+	ch <- prometheus.MustNewConstMetric(bc.scrapeSuccess, prometheus.GaugeValue, success)
+}
+
+// IsScrapeMetric reports whether m is one of the duration and success metrics
+// Run emits, as opposed to the collector's own series.
+func (bc *BaseCollector) IsScrapeMetric(m prometheus.Metric) bool {
+	d := m.Desc()
+	return d == bc.scrapeDuration || d == bc.scrapeSuccess
+}
+
+// LastError returns the error from the most recent Run, or nil if it succeeded.
+func (bc *BaseCollector) LastError() error {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+	return bc.lastErr
 }
 
 // ScrapeContext returns a context with the configured scrape timeout.
@@ -36,6 +175,20 @@ func (bc *BaseCollector) ScrapeContext() (context.Context, context.CancelFunc) {
 	return context.WithTimeout(context.Background(), bc.scrapeTimeout)
 }
 
+// Included reports whether the named object passes the collector's name filter.
+func (bc *BaseCollector) Included(name string) bool {
+	return bc.filter.Match(name)
+}
+
+// InfoLabels returns the labels configured for the collector's info metric,
+// or def when none were configured.
+func (bc *BaseCollector) InfoLabels(def []string) []string {
+	if bc.infoLabels == nil {
+		return def
+	}
+	return bc.infoLabels
+}
+
 // Client returns the SDK client for direct access by collectors
 func (bc *BaseCollector) Client() *vergeos.Client {
 	return bc.client
@@ -66,17 +219,60 @@ func (bc *BaseCollector) GetSystemName(ctx context.Context) (string, error) {
 	return name, nil
 }
 
-// BuildClusterMap creates a mapping from cluster ID to cluster name.
+// BuildClusterMap creates a mapping from cluster ID to cluster name. The map
+// is shared through the API cache, so callers must not modify it.
 func (bc *BaseCollector) BuildClusterMap(ctx context.Context) (map[int]string, error) {
-	clusters, err := bc.client.Clusters.List(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list clusters: %w", err)
-	}
+	return cached(ctx, bc.cache, "Clusters.List", func() (map[int]string, error) {
+		clusters, err := bc.client.Clusters.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list clusters: %w", err)
+		}
 
-	clusterMap := make(map[int]string)
-	for _, cluster := range clusters {
-		clusterMap[int(cluster.Key)] = cluster.Name
-	}
+		clusterMap := make(map[int]string)
+		for _, cluster := range clusters {
+			clusterMap[int(cluster.Key)] = cluster.Name
+		}
+
+		return clusterMap, nil
+	})
+}
+
+// ListMachineStats lists the stats of every machine, shared through the API
+// cache. Callers must not modify the returned slice.
+func (bc *BaseCollector) ListMachineStats(ctx context.Context) ([]vergeos.MachineStats, error) {
+	return cached(ctx, bc.cache, "MachineStats.List", func() ([]vergeos.MachineStats, error) {
+		return bc.client.MachineStats.List(ctx)
+	})
+}
+
+// ListMachineStatus lists the status of every machine, shared through the API
+// cache. Callers must not modify the returned slice.
+func (bc *BaseCollector) ListMachineStatus(ctx context.Context) ([]vergeos.MachineStatus, error) {
+	return cached(ctx, bc.cache, "MachineStatus.List", func() ([]vergeos.MachineStatus, error) {
+		return bc.client.MachineStatus.List(ctx)
+	})
+}
+
+// ListMachineNICs lists every machine NIC, shared through the API cache.
+// Callers must not modify the returned slice.
+func (bc *BaseCollector) ListMachineNICs(ctx context.Context) ([]vergeos.MachineNIC, error) {
+	return cached(ctx, bc.cache, "MachineNICs.List", func() ([]vergeos.MachineNIC, error) {
+		return bc.client.MachineNICs.List(ctx)
+	})
+}
+
+// ListVMs lists every VM that is not a snapshot, shared through the API cache.
+// Callers must not modify the returned slice.
+func (bc *BaseCollector) ListVMs(ctx context.Context) ([]vergeos.VM, error) {
+	return cached(ctx, bc.cache, "VMs.List", func() ([]vergeos.VM, error) {
+		return bc.client.VMs.List(ctx, vergeos.WithFilter("is_snapshot eq false"))
+	})
+}
 
-	return clusterMap, nil
+// ListMachineSnapshots lists every VM snapshot, shared through the API cache.
+// Callers must not modify the returned slice.
+func (bc *BaseCollector) ListMachineSnapshots(ctx context.Context) ([]vergeos.MachineSnapshot, error) {
+	return cached(ctx, bc.cache, "MachineSnapshots.List", func() ([]vergeos.MachineSnapshot, error) {
+		return bc.client.MachineSnapshots.List(ctx)
+	})
 }
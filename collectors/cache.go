@@ -0,0 +1,105 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// APICache shares VergeOS API responses between the collectors of one client
+// while a scrape is in progress, so a table several collectors read (machine
+// stats, machine status, NICs, clusters) is fetched once per scrape rather
+// than once per collector. Outside a scrape every call goes to the API.
+type APICache struct {
+	maxAge time.Duration
+
+	mutex   sync.Mutex
+	active  int
+	entries map[string]*cacheEntry
+}
+
+// cacheEntry is one shared API response. done is closed once value and err
+// are set; callers arriving earlier wait on it rather than issuing the same
+// request again.
+type cacheEntry struct {
+	done    chan struct{}
+	fetched time.Time
+	value   any
+	err     error
+}
+
+// NewAPICache creates an APICache. An entry is reused for at most maxAge (the
+// scrape timeout is a good choice), so back-to-back overlapping scrapes still
+// see fresh data.
+func NewAPICache(maxAge time.Duration) *APICache {
+	return &APICache{
+		maxAge:  maxAge,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Begin marks the start of a scrape and returns the function that ends it.
+// Entries are dropped when the last overlapping scrape ends. Begin on a nil
+// cache is a no-op.
+func (c *APICache) Begin() (end func()) {
+	if c == nil {
+		return func() {}
+	}
+	c.mutex.Lock()
+	c.active++
+	c.mutex.Unlock()
+
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.active--
+		if c.active == 0 {
+			c.entries = make(map[string]*cacheEntry)
+		}
+	}
+}
+
+// cached returns the response stored under key, calling fetch to obtain it if
+// no scrape has yet. key names the SDK call and any filter it was given, e.g.
+// "MachineStats.List". Failed fetches are shared with callers already waiting
+// but not kept, so a later collector retries.
+func cached[T any](ctx context.Context, c *APICache, key string, fetch func() (T, error)) (T, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	c.mutex.Lock()
+	if c.active == 0 {
+		c.mutex.Unlock()
+		return fetch()
+	}
+	// An entry with no fetch time is still in flight.
+	if e, ok := c.entries[key]; ok && (e.fetched.IsZero() || time.Since(e.fetched) < c.maxAge) {
+		c.mutex.Unlock()
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		if e.err != nil {
+			var zero T
+			return zero, e.err
+		}
+		return e.value.(T), nil
+	}
+	e := &cacheEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mutex.Unlock()
+
+	value, err := fetch()
+
+	c.mutex.Lock()
+	e.value, e.err, e.fetched = value, err, time.Now()
+	if err != nil && c.entries[key] == e {
+		delete(c.entries, key)
+	}
+	c.mutex.Unlock()
+	close(e.done)
+	return value, err
+}
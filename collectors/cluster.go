@@ -1,6 +1,8 @@
 package collectors
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -37,9 +39,9 @@ type ClusterCollector struct {
 }
 
 // NewClusterCollector creates a new ClusterCollector
-func NewClusterCollector(client *vergeos.Client, scrapeTimeout time.Duration) *ClusterCollector {
+func NewClusterCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *ClusterCollector {
 	return &ClusterCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("cluster", client, scrapeTimeout, opts...),
 		clusterStatus: prometheus.NewDesc(
 			"vergeos_cluster_status",
 			"Cluster status (1=online, 0=offline)",
@@ -164,6 +166,8 @@ func (cc *ClusterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- cc.clusterOnlineRam
 	ch <- cc.clusterOnlineCores
 	ch <- cc.clusterPhysRamUsed
+
+	cc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -171,21 +175,22 @@ func (cc *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
 	cc.mutex.Lock()
 	defer cc.mutex.Unlock()
 
-	ctx, cancel := cc.ScrapeContext()
-	defer cancel()
+	cc.Run(ch, cc.collect)
+}
 
+// collect fails only if the cluster list can't be fetched; a cluster whose
+// status can't be fetched is logged and skipped.
+func (cc *ClusterCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// Get system name using SDK
 	systemName, err := cc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Get cluster list using SDK
 	clusters, err := cc.client.Clusters.List(ctx)
 	if err != nil {
-		log.Printf("Error fetching clusters: %v", err)
-		return
+		return fmt.Errorf("fetching clusters: %w", err)
 	}
 
 	// Emit total clusters metric
@@ -196,15 +201,17 @@ func (cc *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
 		systemName,
 	)
 
-	// Process each cluster
-	for _, cluster := range clusters {
+	// Process each cluster; the SDK issues one status request per cluster,
+	// so they run concurrently within the collector's limiter
+	cc.FanOut(ctx, len(clusters), func(i int) {
+		cluster := clusters[i]
 		clusterName := cluster.Name
 
 		// Get cluster status using SDK
 		status, err := cc.client.Clusters.GetStatus(ctx, cluster.Key.Int())
 		if err != nil {
 			log.Printf("Error fetching cluster %d (%s) status: %v", cluster.Key, clusterName, err)
-			continue
+			return
 		}
 
 		// Enabled status (1=enabled, 0=disabled)
@@ -332,5 +339,7 @@ func (cc *ClusterCollector) Collect(ch chan<- prometheus.Metric) {
 			healthValue,
 			systemName, clusterName,
 		)
-	}
+	})
+
+	return nil
 }
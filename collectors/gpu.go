@@ -0,0 +1,225 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*GPUCollector)(nil)
+
+// gpuHolder is the VM or tenant whose machine holds a GPU or vGPU instance.
+type gpuHolder struct {
+	holderType, name string
+}
+
+// gpuAssignment identifies the instances of one GPU held by one holder.
+type gpuAssignment struct {
+	gpu    int
+	holder gpuHolder
+}
+
+// GPUCollector collects per-device metrics for the physical GPUs in the
+// cluster's nodes, whether passed through whole or split into vGPUs
+type GPUCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	gpuInfo              *prometheus.Desc
+	gpuInstances         *prometheus.Desc
+	gpuInstancesAssigned *prometheus.Desc
+	gpuInstancesFree     *prometheus.Desc
+	gpuAssignedInstances *prometheus.Desc
+}
+
+// NewGPUCollector creates a new GPUCollector
+func NewGPUCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *GPUCollector {
+	gpuLabels := []string{"system_name", "node", "gpu", "gpu_id", "profile"}
+
+	return &GPUCollector{
+		BaseCollector: *NewBaseCollector("gpu", client, scrapeTimeout, opts...),
+		gpuInfo: prometheus.NewDesc(
+			"vergeos_gpu_info",
+			"Physical GPU in a node (always 1)",
+			[]string{"system_name", "node", "gpu", "gpu_id", "vendor", "model", "mode"},
+			nil,
+		),
+		gpuInstances: prometheus.NewDesc(
+			"vergeos_gpu_instances",
+			"Instances the GPU provides: 1 for passthrough, or the vGPU profile's maximum",
+			gpuLabels,
+			nil,
+		),
+		gpuInstancesAssigned: prometheus.NewDesc(
+			"vergeos_gpu_instances_assigned",
+			"Instances of the GPU assigned to a VM or tenant",
+			gpuLabels,
+			nil,
+		),
+		gpuInstancesFree: prometheus.NewDesc(
+			"vergeos_gpu_instances_free",
+			"Instances of the GPU not assigned to a VM or tenant",
+			gpuLabels,
+			nil,
+		),
+		gpuAssignedInstances: prometheus.NewDesc(
+			"vergeos_gpu_assigned_instances",
+			"Instances of the GPU held by the VM or tenant",
+			append(gpuLabels, "holder_type", "holder"),
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (gc *GPUCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gc.gpuInfo
+	ch <- gc.gpuInstances
+	ch <- gc.gpuInstancesAssigned
+	ch <- gc.gpuInstancesFree
+	ch <- gc.gpuAssignedInstances
+
+	gc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (gc *GPUCollector) Collect(ch chan<- prometheus.Metric) {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	gc.Run(ch, gc.collect)
+}
+
+// collect needs the nodes, GPUs and vGPU instances; holders that can't be
+// resolved fall back to their machine ID.
+func (gc *GPUCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := gc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := gc.Client().Nodes.ListPhysical(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching physical nodes: %w", err)
+	}
+	nodeMap := make(map[int]string, len(nodes))
+	for _, node := range nodes {
+		nodeMap[int(node.ID)] = node.Name
+	}
+
+	gpus, err := gc.Client().NodeGPUs.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching node GPUs: %w", err)
+	}
+
+	vgpus, err := gc.Client().NodeVGPUs.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching vGPU instances: %w", err)
+	}
+
+	holderMap, err := gc.buildHolderMap(ctx)
+	if err != nil {
+		log.Printf("Error resolving GPU holders: %v", err)
+		// Non-fatal: holders fall back to their machine ID
+	}
+
+	// A passthrough GPU is one instance held by the machine it is attached
+	// to; a vGPU GPU's instances are the vGPUs created on it.
+	assigned := make(map[int]int)
+	held := make(map[gpuAssignment]int)
+	for _, gpu := range gpus {
+		if gpu.Mode == "passthrough" && gpu.Machine > 0 {
+			assigned[int(gpu.ID)]++
+			held[gpuAssignment{int(gpu.ID), holderFor(holderMap, gpu.Machine)}]++
+		}
+	}
+	for _, vgpu := range vgpus {
+		if vgpu.Machine > 0 {
+			assigned[vgpu.GPU]++
+			held[gpuAssignment{vgpu.GPU, holderFor(holderMap, vgpu.Machine)}]++
+		}
+	}
+
+	gpuLabels := make(map[int][]string, len(gpus))
+	for _, gpu := range gpus {
+		nodeName := nodeMap[gpu.Node]
+		if nodeName == "" {
+			nodeName = fmt.Sprintf("node_%d", gpu.Node)
+		}
+		// Identical cards in a node share a name, so the ID tells them apart
+		gpuID := fmt.Sprintf("%d", int(gpu.ID))
+		ch <- prometheus.MustNewConstMetric(gc.gpuInfo, prometheus.GaugeValue, 1.0,
+			systemName, nodeName, gpu.Name, gpuID, gpu.Vendor, gpu.Model, gpu.Mode)
+
+		profile, instances := "passthrough", 1
+		if gpu.Mode != "passthrough" {
+			profile, instances = gpu.VGPUProfile, gpu.MaxInstances
+		}
+		labels := []string{systemName, nodeName, gpu.Name, gpuID, profile}
+		gpuLabels[int(gpu.ID)] = labels
+
+		n := assigned[int(gpu.ID)]
+		ch <- prometheus.MustNewConstMetric(gc.gpuInstances, prometheus.GaugeValue, float64(instances), labels...)
+		ch <- prometheus.MustNewConstMetric(gc.gpuInstancesAssigned, prometheus.GaugeValue, float64(n), labels...)
+		ch <- prometheus.MustNewConstMetric(gc.gpuInstancesFree, prometheus.GaugeValue, float64(max(instances-n, 0)), labels...)
+	}
+
+	for a, n := range held {
+		labels, ok := gpuLabels[a.gpu]
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(gc.gpuAssignedInstances, prometheus.GaugeValue, float64(n),
+			append(labels, a.holder.holderType, a.holder.name)...)
+	}
+
+	return nil
+}
+
+// buildHolderMap returns a map of machine ID to the VM or tenant owning the
+// machine. Tenant nodes are machines too, so GPUs given to a tenant resolve
+// to the tenant.
+func (gc *GPUCollector) buildHolderMap(ctx context.Context) (map[int]gpuHolder, error) {
+	holderMap := make(map[int]gpuHolder)
+
+	vms, err := gc.ListVMs(ctx)
+	if err != nil {
+		return holderMap, fmt.Errorf("failed to list VMs: %w", err)
+	}
+	for _, vm := range vms {
+		holderMap[vm.Machine] = gpuHolder{"vm", vm.Name}
+	}
+
+	tenants, err := gc.Client().Tenants.List(ctx)
+	if err != nil {
+		return holderMap, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	tenantMap := make(map[int]string, len(tenants))
+	for _, t := range tenants {
+		tenantMap[int(t.ID)] = t.Name
+	}
+
+	tenantNodes, err := gc.Client().TenantNodes.List(ctx)
+	if err != nil {
+		return holderMap, fmt.Errorf("failed to list tenant nodes: %w", err)
+	}
+	for _, node := range tenantNodes {
+		holderMap[int(node.Machine)] = gpuHolder{"tenant", tenantName(tenantMap, int(node.Tenant))}
+	}
+
+	return holderMap, nil
+}
+
+// holderFor resolves a machine to its holder, falling back to
+// "machine_<id>" for machines that are neither a VM nor a tenant node.
+func holderFor(holderMap map[int]gpuHolder, machine int) gpuHolder {
+	if h, ok := holderMap[machine]; ok {
+		return h
+	}
+	return gpuHolder{"machine", fmt.Sprintf("machine_%d", machine)}
+}
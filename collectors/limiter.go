@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultConcurrency is the number of per-object API requests a Limiter
+// allows in flight when none is configured.
+const DefaultConcurrency = 8
+
+// Limiter bounds the per-object API requests (one per tenant, cluster or
+// network) in flight against one VergeOS cloud. Collectors built on the same
+// client should share one Limiter so the bound holds across all of them, not
+// per collector.
+type Limiter struct {
+	sem chan struct{}
+}
+
+// NewLimiter creates a Limiter allowing n concurrent requests. n below 1 is
+// treated as 1.
+func NewLimiter(n int) *Limiter {
+	if n < 1 {
+		n = 1
+	}
+	return &Limiter{sem: make(chan struct{}, n)}
+}
+
+// WithLimiter makes the collector's fan-out paths share l.
+func WithLimiter(l *Limiter) Option {
+	return func(bc *BaseCollector) {
+		bc.limiter = l
+	}
+}
+
+// FanOut calls fn(0) through fn(n-1) concurrently, at most as many at once as
+// the collector's Limiter allows, and waits for them all. Calls still waiting
+// for a slot when ctx is done are skipped. fn may send to the collector's
+// metric channel, which is safe for concurrent use.
+func (bc *BaseCollector) FanOut(ctx context.Context, n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			select {
+			case bc.limiter.sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-bc.limiter.sem }()
+			if ctx.Err() != nil {
+				return
+			}
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
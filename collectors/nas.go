@@ -0,0 +1,273 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*NASCollector)(nil)
+
+// nasShareProtocols are the share protocols counted per volume, each emitted
+// on every scrape so that a volume without shares reports 0.
+var nasShareProtocols = []string{"cifs", "nfs"}
+
+// NASCollector collects metrics for VergeOS NAS services, their volumes, and
+// the sync jobs copying between volumes
+type NASCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	// Service metrics
+	nasServiceRunning *prometheus.Desc
+
+	// Volume metrics
+	nasVolumeSize      *prometheus.Desc
+	nasVolumeUsed      *prometheus.Desc
+	nasVolumeFree      *prometheus.Desc
+	nasVolumeSnapshots *prometheus.Desc
+	nasVolumeShares    *prometheus.Desc
+
+	// Volume sync metrics
+	nasSyncEnabled *prometheus.Desc
+	nasSyncRunning *prometheus.Desc
+	nasSyncError   *prometheus.Desc
+	nasSyncLastRun *prometheus.Desc
+}
+
+// NewNASCollector creates a new NASCollector
+func NewNASCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *NASCollector {
+	volumeLabels := []string{"system_name", "nas_service", "volume"}
+	syncLabels := []string{"system_name", "nas_service", "sync_name", "source_volume", "destination_volume"}
+
+	return &NASCollector{
+		BaseCollector: *NewBaseCollector("nas", client, scrapeTimeout, opts...),
+		nasServiceRunning: prometheus.NewDesc(
+			"vergeos_nas_service_running",
+			"Whether the NAS service is running (1=running, 0=not running)",
+			[]string{"system_name", "nas_service"},
+			nil,
+		),
+		nasVolumeSize: prometheus.NewDesc(
+			"vergeos_nas_volume_size_bytes",
+			"Configured maximum size of the NAS volume in bytes (0=unlimited)",
+			volumeLabels,
+			nil,
+		),
+		nasVolumeUsed: prometheus.NewDesc(
+			"vergeos_nas_volume_used_bytes",
+			"Used space on the NAS volume in bytes",
+			volumeLabels,
+			nil,
+		),
+		nasVolumeFree: prometheus.NewDesc(
+			"vergeos_nas_volume_free_bytes",
+			"Free space on the NAS volume in bytes, for volumes with a maximum size",
+			volumeLabels,
+			nil,
+		),
+		nasVolumeSnapshots: prometheus.NewDesc(
+			"vergeos_nas_volume_snapshots",
+			"Number of snapshots of the NAS volume",
+			volumeLabels,
+			nil,
+		),
+		nasVolumeShares: prometheus.NewDesc(
+			"vergeos_nas_volume_shares",
+			"Number of shares of the NAS volume by protocol",
+			append(volumeLabels, "protocol"),
+			nil,
+		),
+		nasSyncEnabled: prometheus.NewDesc(
+			"vergeos_nas_volume_sync_enabled",
+			"Whether the volume sync job is enabled (1=enabled, 0=disabled)",
+			syncLabels,
+			nil,
+		),
+		nasSyncRunning: prometheus.NewDesc(
+			"vergeos_nas_volume_sync_running",
+			"Whether the volume sync job is running (1=running, 0=idle)",
+			syncLabels,
+			nil,
+		),
+		nasSyncError: prometheus.NewDesc(
+			"vergeos_nas_volume_sync_error",
+			"Whether the volume sync job is in an error state (1=error, 0=ok)",
+			syncLabels,
+			nil,
+		),
+		nasSyncLastRun: prometheus.NewDesc(
+			"vergeos_nas_volume_sync_last_run_timestamp_seconds",
+			"Unix time the volume sync job's last run finished",
+			syncLabels,
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (nc *NASCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nc.nasServiceRunning
+	ch <- nc.nasVolumeSize
+	ch <- nc.nasVolumeUsed
+	ch <- nc.nasVolumeFree
+	ch <- nc.nasVolumeSnapshots
+	ch <- nc.nasVolumeShares
+	ch <- nc.nasSyncEnabled
+	ch <- nc.nasSyncRunning
+	ch <- nc.nasSyncError
+	ch <- nc.nasSyncLastRun
+
+	nc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (nc *NASCollector) Collect(ch chan<- prometheus.Metric) {
+	nc.mutex.Lock()
+	defer nc.mutex.Unlock()
+
+	nc.Run(ch, nc.collect)
+}
+
+func (nc *NASCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := nc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	services, err := nc.Client().NASServices.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching NAS services: %w", err)
+	}
+
+	// A NAS service runs as a VM; its machine status says whether it is up.
+	// running stays nil when the statuses can't be fetched, so the services
+	// are skipped rather than reported as down.
+	var running map[int]bool
+	allStatuses, err := nc.ListMachineStatus(ctx)
+	if err != nil {
+		log.Printf("Error batch-fetching machine statuses (service running state skipped): %v", err)
+	} else {
+		running = make(map[int]bool, len(allStatuses))
+		for _, status := range allStatuses {
+			running[status.Machine] = status.Running
+		}
+	}
+
+	serviceMap := make(map[int]string, len(services))
+	for _, svc := range services {
+		serviceMap[int(svc.ID)] = svc.Name
+		if running != nil {
+			ch <- prometheus.MustNewConstMetric(nc.nasServiceRunning, prometheus.GaugeValue,
+				boolToFloat64(running[svc.Machine]), systemName, svc.Name)
+		}
+	}
+
+	return errors.Join(
+		nc.collectVolumeMetrics(ctx, ch, systemName, serviceMap),
+		nc.collectSyncMetrics(ctx, ch, systemName, serviceMap),
+	)
+}
+
+// collectVolumeMetrics emits space, snapshot and share metrics per volume.
+// Snapshot and share counts are skipped when their list can't be fetched,
+// rather than reported as 0; space is still reported.
+func (nc *NASCollector) collectVolumeMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, serviceMap map[int]string) error {
+	volumes, err := nc.Client().NASVolumes.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching NAS volumes: %w", err)
+	}
+
+	var snapshotCounts map[string]int
+	snapshots, err := nc.Client().NASVolumeSnapshots.List(ctx)
+	if err != nil {
+		log.Printf("Error fetching NAS volume snapshots (snapshot counts skipped): %v", err)
+	} else {
+		snapshotCounts = make(map[string]int)
+		for _, snap := range snapshots {
+			snapshotCounts[snap.Volume]++
+		}
+	}
+
+	// shareCounts has an entry only for the protocols whose shares were fetched
+	shareCounts := make(map[string]map[string]int, len(nasShareProtocols))
+	cifsShares, err := nc.Client().CIFSShares.List(ctx)
+	if err != nil {
+		log.Printf("Error fetching CIFS shares (CIFS share counts skipped): %v", err)
+	} else {
+		shareCounts["cifs"] = make(map[string]int)
+		for _, share := range cifsShares {
+			shareCounts["cifs"][share.Volume]++
+		}
+	}
+	nfsShares, err := nc.Client().NFSShares.List(ctx)
+	if err != nil {
+		log.Printf("Error fetching NFS shares (NFS share counts skipped): %v", err)
+	} else {
+		shareCounts["nfs"] = make(map[string]int)
+		for _, share := range nfsShares {
+			shareCounts["nfs"][share.Volume]++
+		}
+	}
+
+	for _, vol := range volumes {
+		labels := []string{systemName, nasServiceName(serviceMap, vol.Service), vol.Name}
+
+		ch <- prometheus.MustNewConstMetric(nc.nasVolumeSize, prometheus.GaugeValue, float64(vol.MaxSize), labels...)
+		ch <- prometheus.MustNewConstMetric(nc.nasVolumeUsed, prometheus.GaugeValue, float64(vol.UsedBytes), labels...)
+		if vol.MaxSize > 0 {
+			ch <- prometheus.MustNewConstMetric(nc.nasVolumeFree, prometheus.GaugeValue, float64(max(vol.MaxSize-vol.UsedBytes, 0)), labels...)
+		}
+
+		if snapshotCounts != nil {
+			ch <- prometheus.MustNewConstMetric(nc.nasVolumeSnapshots, prometheus.GaugeValue, float64(snapshotCounts[vol.ID]), labels...)
+		}
+		for _, protocol := range nasShareProtocols {
+			counts, ok := shareCounts[protocol]
+			if !ok {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(nc.nasVolumeShares, prometheus.GaugeValue,
+				float64(counts[vol.ID]), append(labels, protocol)...)
+		}
+	}
+
+	return nil
+}
+
+// collectSyncMetrics emits the state of the volume sync jobs. The last-run
+// timestamp is omitted until the job has run.
+func (nc *NASCollector) collectSyncMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, serviceMap map[int]string) error {
+	syncs, err := nc.Client().NASVolumeSyncs.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching NAS volume syncs: %w", err)
+	}
+
+	for _, s := range syncs {
+		labels := []string{systemName, nasServiceName(serviceMap, s.Service), s.Name, s.SourceVolumeName, s.DestinationVolumeName}
+
+		ch <- prometheus.MustNewConstMetric(nc.nasSyncEnabled, prometheus.GaugeValue, boolToFloat64(s.Enabled), labels...)
+		ch <- prometheus.MustNewConstMetric(nc.nasSyncRunning, prometheus.GaugeValue, boolToFloat64(s.Status == "syncing"), labels...)
+		ch <- prometheus.MustNewConstMetric(nc.nasSyncError, prometheus.GaugeValue, boolToFloat64(s.Status == "error"), labels...)
+		if s.LastRun > 0 {
+			ch <- prometheus.MustNewConstMetric(nc.nasSyncLastRun, prometheus.GaugeValue, float64(s.LastRun), labels...)
+		}
+	}
+
+	return nil
+}
+
+// nasServiceName resolves a NAS service ID, falling back to "nas_<id>" for
+// unknown services.
+func nasServiceName(serviceMap map[int]string, id int) string {
+	if name := serviceMap[id]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("nas_%d", id)
+}
@@ -1,8 +1,8 @@
 package collectors
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
@@ -27,11 +27,11 @@ type NetworkCollector struct {
 }
 
 // NewNetworkCollector creates a new NetworkCollector.
-func NewNetworkCollector(client *vergeos.Client, scrapeTimeout time.Duration) *NetworkCollector {
+func NewNetworkCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *NetworkCollector {
 	nicLabels := []string{"system_name", "cluster", "node_name", "interface"}
 
 	return &NetworkCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("network", client, scrapeTimeout, opts...),
 		nicTxPackets: prometheus.NewDesc(
 			"vergeos_nic_tx_packets_total",
 			"Total transmitted packets",
@@ -67,6 +67,8 @@ func (nc *NetworkCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- nc.nicTxBytes
 	ch <- nc.nicRxBytes
 	ch <- nc.nicStatus
+
+	nc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector.
@@ -74,35 +76,32 @@ func (nc *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
 	nc.mutex.Lock()
 	defer nc.mutex.Unlock()
 
-	ctx, cancel := nc.ScrapeContext()
-	defer cancel()
+	nc.Run(ch, nc.collect)
+}
 
+func (nc *NetworkCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// Get system name for labeling
 	systemName, err := nc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("NetworkCollector: Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Build cluster ID -> name mapping
 	clusterMap, err := nc.BuildClusterMap(ctx)
 	if err != nil {
-		log.Printf("NetworkCollector: Error building cluster map: %v", err)
-		return
+		return fmt.Errorf("building cluster map: %w", err)
 	}
 
 	// Get physical nodes
 	nodes, err := nc.Client().Nodes.ListPhysical(ctx)
 	if err != nil {
-		log.Printf("NetworkCollector: Error fetching physical nodes: %v", err)
-		return
+		return fmt.Errorf("fetching physical nodes: %w", err)
 	}
 
 	// Batch-fetch all NICs (avoids N+1 per-node API calls)
-	allNICs, err := nc.Client().MachineNICs.List(ctx)
+	allNICs, err := nc.ListMachineNICs(ctx)
 	if err != nil {
-		log.Printf("NetworkCollector: Error fetching NICs: %v", err)
-		return
+		return fmt.Errorf("fetching NICs: %w", err)
 	}
 	nicMap := make(map[int][]vergeos.MachineNIC)
 	for _, nic := range allNICs {
@@ -110,6 +109,9 @@ func (nc *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	for _, node := range nodes {
+		if !nc.Included(node.Name) {
+			continue
+		}
 		clusterName := clusterMap[node.Cluster]
 		if clusterName == "" {
 			clusterName = fmt.Sprintf("cluster_%d", node.Cluster)
@@ -151,4 +153,6 @@ func (nc *NetworkCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 	}
+
+	return nil
 }
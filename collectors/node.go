@@ -1,8 +1,10 @@
 package collectors
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +14,18 @@ import (
 
 var _ prometheus.Collector = (*NodeCollector)(nil)
 
+// sensorUnits maps the units IPMI reports to the names used in the unit
+// label. Other units are lowercased with spaces replaced by underscores.
+var sensorUnits = map[string]string{
+	"degrees C": "celsius",
+	"degrees F": "fahrenheit",
+	"RPM":       "rpm",
+	"Volts":     "volts",
+	"Watts":     "watts",
+	"Amps":      "amperes",
+	"percent":   "percent",
+}
+
 // NodeCollector collects metrics about VergeOS physical nodes
 type NodeCollector struct {
 	BaseCollector
@@ -36,14 +50,23 @@ type NodeCollector struct {
 	// VM aggregate metrics (Issue 7)
 	nodeRunningCores *prometheus.Desc
 	nodeRunningRAM   *prometheus.Desc
+
+	// IPMI sensor metrics
+	nodeSensorValue *prometheus.Desc
+	nodeSensorOK    *prometheus.Desc
+
+	// Inventory and lifecycle metrics
+	nodeInfo        *prometheus.Desc
+	nodeMaintenance *prometheus.Desc
+	nodeUptime      *prometheus.Desc
 }
 
 // NewNodeCollector creates a new NodeCollector
-func NewNodeCollector(client *vergeos.Client, scrapeTimeout time.Duration) *NodeCollector {
+func NewNodeCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *NodeCollector {
 	nodeLabels := []string{"system_name", "cluster", "node_name"}
 
 	nc := &NodeCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("node", client, scrapeTimeout, opts...),
 		nodesTotal: prometheus.NewDesc(
 			"vergeos_nodes_total",
 			"Total number of physical nodes",
@@ -128,6 +151,36 @@ func NewNodeCollector(client *vergeos.Client, scrapeTimeout time.Duration) *Node
 			nodeLabels,
 			nil,
 		),
+		nodeSensorValue: prometheus.NewDesc(
+			"vergeos_node_sensor_value",
+			"Reading of the node's IPMI sensor, in the sensor's unit",
+			append(nodeLabels, "sensor", "sensor_id", "type", "unit"),
+			nil,
+		),
+		nodeSensorOK: prometheus.NewDesc(
+			"vergeos_node_sensor_ok",
+			"Whether the node's IPMI sensor reports an ok status (1=ok, 0=warning, critical or failed)",
+			append(nodeLabels, "sensor", "sensor_id", "type"),
+			nil,
+		),
+		nodeInfo: prometheus.NewDesc(
+			"vergeos_node_info",
+			"Hardware, firmware and software inventory of the node (always 1)",
+			append(nodeLabels, "vendor", "model", "bios_version", "bmc_version", "cpu_model", "cores", "threads", "vergeos_version", "maintenance_mode"),
+			nil,
+		),
+		nodeMaintenance: prometheus.NewDesc(
+			"vergeos_node_maintenance_mode",
+			"Whether the node is in maintenance mode (1=maintenance, 0=normal)",
+			nodeLabels,
+			nil,
+		),
+		nodeUptime: prometheus.NewDesc(
+			"vergeos_node_uptime_seconds",
+			"Seconds since the node last started",
+			nodeLabels,
+			nil,
+		),
 	}
 
 	return nc
@@ -149,6 +202,13 @@ func (nc *NodeCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- nc.nodeRAMPct
 	ch <- nc.nodeRunningCores
 	ch <- nc.nodeRunningRAM
+	ch <- nc.nodeSensorValue
+	ch <- nc.nodeSensorOK
+	ch <- nc.nodeInfo
+	ch <- nc.nodeMaintenance
+	ch <- nc.nodeUptime
+
+	nc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -156,32 +216,32 @@ func (nc *NodeCollector) Collect(ch chan<- prometheus.Metric) {
 	nc.mutex.Lock()
 	defer nc.mutex.Unlock()
 
-	ctx, cancel := nc.ScrapeContext()
-	defer cancel()
+	nc.Run(ch, nc.collect)
+}
 
+// collect needs the physical nodes; machine stats and IPMI sensors are
+// best-effort, so a node without them still reports its inventory and RAM.
+func (nc *NodeCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// Get system name using SDK
 	systemName, err := nc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Build cluster ID -> name mapping
 	clusterMap, err := nc.BuildClusterMap(ctx)
 	if err != nil {
-		log.Printf("Error building cluster map: %v", err)
-		return
+		return fmt.Errorf("building cluster map: %w", err)
 	}
 
 	// Get physical nodes using SDK
 	nodes, err := nc.Client().Nodes.ListPhysical(ctx)
 	if err != nil {
-		log.Printf("Error fetching physical nodes: %v", err)
-		return
+		return fmt.Errorf("fetching physical nodes: %w", err)
 	}
 
 	// Batch-fetch machine stats (avoids N+1 per-node API calls)
-	allStats, err := nc.Client().MachineStats.List(ctx)
+	allStats, err := nc.ListMachineStats(ctx)
 	if err != nil {
 		log.Printf("Error batch-fetching machine stats: %v", err)
 		// Continue without stats — node metadata can still be emitted
@@ -191,11 +251,28 @@ func (nc *NodeCollector) Collect(ch chan<- prometheus.Metric) {
 		statsMap[allStats[i].Machine] = &allStats[i]
 	}
 
+	// Batch-fetch IPMI sensor readings for every node
+	allSensors, err := nc.Client().NodeIPMISensors.List(ctx)
+	if err != nil {
+		log.Printf("Error batch-fetching IPMI sensors: %v", err)
+		// Continue without sensors — nodes without a BMC have none anyway
+	}
+	sensorMap := make(map[int][]vergeos.NodeIPMISensor)
+	for _, sensor := range allSensors {
+		sensorMap[sensor.Node] = append(sensorMap[sensor.Node], sensor)
+	}
+
 	// Count nodes per cluster
 	clusterNodeCounts := make(map[string]int)
 
+	now := time.Now()
+
 	// Process each node
 	for _, node := range nodes {
+		if !nc.Included(node.Name) {
+			continue
+		}
+
 		// Get cluster name from mapping
 		clusterName := clusterMap[node.Cluster]
 		if clusterName == "" {
@@ -218,6 +295,32 @@ func (nc *NodeCollector) Collect(ch chan<- prometheus.Metric) {
 			systemName, clusterName, node.Name,
 		)
 
+		// Inventory and lifecycle
+		ch <- prometheus.MustNewConstMetric(
+			nc.nodeInfo,
+			prometheus.GaugeValue,
+			1.0,
+			systemName, clusterName, node.Name,
+			node.Vendor, node.Model, node.BIOSVersion, node.BMCVersion, node.CPUModel,
+			fmt.Sprintf("%d", node.Cores), fmt.Sprintf("%d", node.Threads), node.Version,
+			fmt.Sprintf("%t", node.Maintenance),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			nc.nodeMaintenance,
+			prometheus.GaugeValue,
+			boolToFloat64(node.Maintenance),
+			systemName, clusterName, node.Name,
+		)
+		// Started is 0 while the node is down
+		if node.Started > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				nc.nodeUptime,
+				prometheus.GaugeValue,
+				now.Sub(time.Unix(node.Started, 0)).Seconds(),
+				systemName, clusterName, node.Name,
+			)
+		}
+
 		// RAM total
 		ch <- prometheus.MustNewConstMetric(
 			nc.nodeRAMTotal,
@@ -250,6 +353,11 @@ func (nc *NodeCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 
+		// IPMI sensors
+		for _, sensor := range sensorMap[int(node.ID)] {
+			nc.collectSensor(ch, sensor, systemName, clusterName, node.Name)
+		}
+
 		// Look up pre-fetched machine stats
 		stats, ok := statsMap[node.Machine]
 		if !ok {
@@ -318,4 +426,39 @@ func (nc *NodeCollector) Collect(ch chan<- prometheus.Metric) {
 			systemName, clusterName,
 		)
 	}
+
+	return nil
+}
+
+// collectSensor emits one IPMI sensor's status and reading. Sensors with no
+// reading ("ns") report neither; discrete sensors, such as PSU presence, have
+// no unit and report only their status. BMCs may give several sensors the
+// same name, so each is also labeled with its ID.
+func (nc *NodeCollector) collectSensor(ch chan<- prometheus.Metric, sensor vergeos.NodeIPMISensor, systemName, clusterName, nodeName string) {
+	if sensor.Status == "ns" {
+		return
+	}
+	sensorType := strings.ToLower(sensor.Type)
+	sensorID := fmt.Sprintf("%d", int(sensor.ID))
+
+	ch <- prometheus.MustNewConstMetric(
+		nc.nodeSensorOK,
+		prometheus.GaugeValue,
+		boolToFloat64(sensor.Status == "ok"),
+		systemName, clusterName, nodeName, sensor.Name, sensorID, sensorType,
+	)
+
+	if sensor.Unit == "" {
+		return
+	}
+	unit, ok := sensorUnits[sensor.Unit]
+	if !ok {
+		unit = strings.ReplaceAll(strings.ToLower(sensor.Unit), " ", "_")
+	}
+	ch <- prometheus.MustNewConstMetric(
+		nc.nodeSensorValue,
+		prometheus.GaugeValue,
+		sensor.Value,
+		systemName, clusterName, nodeName, sensor.Name, sensorID, sensorType, unit,
+	)
 }
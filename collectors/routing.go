@@ -0,0 +1,231 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*RoutingCollector)(nil)
+
+// RoutingCollector collects per-neighbor BGP and OSPF session metrics for the
+// virtual networks running dynamic routing
+type RoutingCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	// Per-vnet metrics
+	routingNeighbors   *prometheus.Desc
+	routingNeighborsUp *prometheus.Desc
+
+	// Per-neighbor metrics
+	routingNeighborUp         *prometheus.Desc
+	routingSessionUptime      *prometheus.Desc
+	routingPrefixesReceived   *prometheus.Desc
+	routingPrefixesAdvertised *prometheus.Desc
+}
+
+// NewRoutingCollector creates a new RoutingCollector
+func NewRoutingCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *RoutingCollector {
+	vnetProtocolLabels := []string{"system_name", "vnet_name", "vnet_id", "cluster", "type", "layer2_type", "protocol"}
+	neighborLabels := append(vnetProtocolLabels, "neighbor")
+
+	return &RoutingCollector{
+		BaseCollector: *NewBaseCollector("routing", client, scrapeTimeout, opts...),
+		routingNeighbors: prometheus.NewDesc(
+			"vergeos_routing_neighbors",
+			"Number of configured routing neighbors on the virtual network by protocol",
+			vnetProtocolLabels, nil,
+		),
+		routingNeighborsUp: prometheus.NewDesc(
+			"vergeos_routing_neighbors_up",
+			"Number of routing neighbors on the virtual network with an established session by protocol",
+			vnetProtocolLabels, nil,
+		),
+		routingNeighborUp: prometheus.NewDesc(
+			"vergeos_routing_neighbor_up",
+			"Whether the session with the routing neighbor is established (1=up, 0=down)",
+			neighborLabels, nil,
+		),
+		routingSessionUptime: prometheus.NewDesc(
+			"vergeos_routing_session_uptime_seconds",
+			"Seconds the session with the routing neighbor has been established",
+			neighborLabels, nil,
+		),
+		routingPrefixesReceived: prometheus.NewDesc(
+			"vergeos_routing_prefixes_received",
+			"Prefixes received from the BGP neighbor",
+			neighborLabels, nil,
+		),
+		routingPrefixesAdvertised: prometheus.NewDesc(
+			"vergeos_routing_prefixes_advertised",
+			"Prefixes advertised to the BGP neighbor",
+			neighborLabels, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (rc *RoutingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.routingNeighbors
+	ch <- rc.routingNeighborsUp
+	ch <- rc.routingNeighborUp
+	ch <- rc.routingSessionUptime
+	ch <- rc.routingPrefixesReceived
+	ch <- rc.routingPrefixesAdvertised
+
+	rc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (rc *RoutingCollector) Collect(ch chan<- prometheus.Metric) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	rc.Run(ch, rc.collect)
+}
+
+func (rc *RoutingCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := rc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	clusterMap, err := rc.BuildClusterMap(ctx)
+	if err != nil {
+		return fmt.Errorf("building cluster map: %w", err)
+	}
+
+	networks, err := rc.Client().Networks.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching networks: %w", err)
+	}
+	netLabels := make(map[int][]string, len(networks))
+	for _, network := range networks {
+		if rc.Included(network.Name) {
+			netLabels[int(network.ID)] = vnetLabels(systemName, network, clusterMap)
+		}
+	}
+
+	return errors.Join(
+		rc.collectBGP(ctx, ch, netLabels),
+		rc.collectOSPF(ctx, ch, netLabels),
+	)
+}
+
+// routingNeighbor is one BGP or OSPF neighbor, in common form.
+type routingNeighbor struct {
+	network int
+	name    string
+	up      bool
+	uptime  int64
+}
+
+// emitNeighbors emits the per-neighbor up and uptime metrics, then the
+// per-vnet neighbor counts. It returns each emitted neighbor's labels, in
+// order, for protocol-specific metrics; nil entries mark skipped neighbors.
+func (rc *RoutingCollector) emitNeighbors(ch chan<- prometheus.Metric, protocol string, neighbors []routingNeighbor, netLabels map[int][]string) [][]string {
+	total := make(map[int]int)
+	up := make(map[int]int)
+	emitted := make([][]string, len(neighbors))
+	for i, n := range neighbors {
+		labels, ok := netLabels[n.network]
+		if !ok {
+			continue
+		}
+		total[n.network]++
+		if n.up {
+			up[n.network]++
+		}
+
+		neighborLabels := append(append([]string{}, labels...), protocol, n.name)
+		emitted[i] = neighborLabels
+		ch <- prometheus.MustNewConstMetric(rc.routingNeighborUp, prometheus.GaugeValue, boolToFloat64(n.up), neighborLabels...)
+		if n.up && n.uptime > 0 {
+			ch <- prometheus.MustNewConstMetric(rc.routingSessionUptime, prometheus.GaugeValue, float64(n.uptime), neighborLabels...)
+		}
+	}
+
+	for network, n := range total {
+		labels := append(append([]string{}, netLabels[network]...), protocol)
+		ch <- prometheus.MustNewConstMetric(rc.routingNeighbors, prometheus.GaugeValue, float64(n), labels...)
+		ch <- prometheus.MustNewConstMetric(rc.routingNeighborsUp, prometheus.GaugeValue, float64(up[network]), labels...)
+	}
+	return emitted
+}
+
+// collectBGP emits metrics for each BGP neighbor. A neighbor is up in the
+// Established state; prefix counts are only meaningful then.
+func (rc *RoutingCollector) collectBGP(ctx context.Context, ch chan<- prometheus.Metric, netLabels map[int][]string) error {
+	peers, err := rc.Client().NetworkBGPNeighbors.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching BGP neighbors: %w", err)
+	}
+
+	neighbors := make([]routingNeighbor, len(peers))
+	for i, p := range peers {
+		neighbors[i] = routingNeighbor{
+			network: p.Network,
+			name:    p.Neighbor,
+			up:      strings.EqualFold(p.State, "established"),
+			uptime:  p.Uptime,
+		}
+	}
+
+	for i, labels := range rc.emitNeighbors(ch, "bgp", neighbors, netLabels) {
+		if labels == nil || !neighbors[i].up {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(rc.routingPrefixesReceived, prometheus.GaugeValue, float64(peers[i].PrefixesReceived), labels...)
+		ch <- prometheus.MustNewConstMetric(rc.routingPrefixesAdvertised, prometheus.GaugeValue, float64(peers[i].PrefixesAdvertised), labels...)
+	}
+	return nil
+}
+
+// collectOSPF emits metrics for each OSPF neighbor. An adjacency is up once
+// Full (e.g. "Full/DR"); 2-Way is normal between two DROther routers, so it
+// counts as up too. A router ID can form adjacencies over several interfaces
+// of a vnet; they are reported as one neighbor, up only while all of them
+// are, with the shortest uptime.
+func (rc *RoutingCollector) collectOSPF(ctx context.Context, ch chan<- prometheus.Metric, netLabels map[int][]string) error {
+	peers, err := rc.Client().NetworkOSPFNeighbors.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching OSPF neighbors: %w", err)
+	}
+
+	type neighborKey struct {
+		network int
+		id      string
+	}
+	index := make(map[neighborKey]int, len(peers))
+	neighbors := make([]routingNeighbor, 0, len(peers))
+	for _, p := range peers {
+		state := strings.ToLower(p.State)
+		up := strings.HasPrefix(state, "full") || strings.HasPrefix(state, "2-way")
+
+		key := neighborKey{p.Network, p.NeighborID}
+		i, seen := index[key]
+		if !seen {
+			index[key] = len(neighbors)
+			neighbors = append(neighbors, routingNeighbor{
+				network: p.Network,
+				name:    p.NeighborID,
+				up:      up,
+				uptime:  p.Uptime,
+			})
+			continue
+		}
+		n := &neighbors[i]
+		n.up = n.up && up
+		n.uptime = min(n.uptime, p.Uptime)
+	}
+
+	rc.emitNeighbors(ch, "ospf", neighbors, netLabels)
+	return nil
+}
@@ -0,0 +1,240 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*SiteSyncCollector)(nil)
+
+// SiteSyncCollector collects per-sync replication metrics for outgoing and
+// incoming site syncs
+type SiteSyncCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	// State metrics
+	siteSyncEnabled *prometheus.Desc
+	siteSyncSyncing *prometheus.Desc
+	siteSyncError   *prometheus.Desc
+
+	// Last run metrics
+	siteSyncLastRunStart  *prometheus.Desc
+	siteSyncLastRunFinish *prometheus.Desc
+	siteSyncLastRunBytes  *prometheus.Desc
+
+	// Outgoing-only metrics
+	siteSyncQueued   *prometheus.Desc
+	siteSyncThrottle *prometheus.Desc
+}
+
+// NewSiteSyncCollector creates a new SiteSyncCollector
+func NewSiteSyncCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *SiteSyncCollector {
+	syncLabels := []string{"system_name", "sync_name", "direction", "remote_site"}
+
+	return &SiteSyncCollector{
+		BaseCollector: *NewBaseCollector("sitesync", client, scrapeTimeout, opts...),
+		siteSyncEnabled: prometheus.NewDesc(
+			"vergeos_site_sync_enabled",
+			"Whether the site sync is enabled (1=enabled, 0=disabled)",
+			syncLabels,
+			nil,
+		),
+		siteSyncSyncing: prometheus.NewDesc(
+			"vergeos_site_sync_syncing",
+			"Whether the site sync is transferring (1=syncing, 0=idle)",
+			syncLabels,
+			nil,
+		),
+		siteSyncError: prometheus.NewDesc(
+			"vergeos_site_sync_error",
+			"Whether the site sync is in an error state (1=error, 0=ok)",
+			syncLabels,
+			nil,
+		),
+		siteSyncLastRunStart: prometheus.NewDesc(
+			"vergeos_site_sync_last_run_start_timestamp_seconds",
+			"Unix time the site sync's last run started",
+			syncLabels,
+			nil,
+		),
+		siteSyncLastRunFinish: prometheus.NewDesc(
+			"vergeos_site_sync_last_run_finish_timestamp_seconds",
+			"Unix time the site sync's last run finished",
+			syncLabels,
+			nil,
+		),
+		siteSyncLastRunBytes: prometheus.NewDesc(
+			"vergeos_site_sync_last_run_transferred_bytes",
+			"Bytes transferred by the site sync's last run",
+			syncLabels,
+			nil,
+		),
+		siteSyncQueued: prometheus.NewDesc(
+			"vergeos_site_sync_queued_snapshots",
+			"Number of snapshots queued to send on the outgoing site sync",
+			syncLabels,
+			nil,
+		),
+		siteSyncThrottle: prometheus.NewDesc(
+			"vergeos_site_sync_throttle_bytes_per_second",
+			"Bandwidth limit of the outgoing site sync in bytes per second (0=unlimited)",
+			syncLabels,
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (sc *SiteSyncCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sc.siteSyncEnabled
+	ch <- sc.siteSyncSyncing
+	ch <- sc.siteSyncError
+	ch <- sc.siteSyncLastRunStart
+	ch <- sc.siteSyncLastRunFinish
+	ch <- sc.siteSyncLastRunBytes
+	ch <- sc.siteSyncQueued
+	ch <- sc.siteSyncThrottle
+
+	sc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (sc *SiteSyncCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.Run(ch, sc.collect)
+}
+
+func (sc *SiteSyncCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := sc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	siteMap, err := sc.buildSiteMap(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching sites: %w", err)
+	}
+
+	return errors.Join(
+		sc.collectOutgoingMetrics(ctx, ch, systemName, siteMap),
+		sc.collectIncomingMetrics(ctx, ch, systemName, siteMap),
+	)
+}
+
+// siteSyncRun is the state and last run of one site sync, in either direction.
+type siteSyncRun struct {
+	enabled               bool
+	status                string
+	lastStart, lastFinish int64
+	lastBytes             int64
+}
+
+// emitRun emits the state and last-run metrics shared by both directions. The
+// last-run timestamps are omitted until the sync has run.
+func (sc *SiteSyncCollector) emitRun(ch chan<- prometheus.Metric, run siteSyncRun, labels []string) {
+	ch <- prometheus.MustNewConstMetric(sc.siteSyncEnabled, prometheus.GaugeValue, boolToFloat64(run.enabled), labels...)
+	ch <- prometheus.MustNewConstMetric(sc.siteSyncSyncing, prometheus.GaugeValue, boolToFloat64(run.status == "syncing"), labels...)
+	ch <- prometheus.MustNewConstMetric(sc.siteSyncError, prometheus.GaugeValue, boolToFloat64(run.status == "error"), labels...)
+
+	if run.lastStart > 0 {
+		ch <- prometheus.MustNewConstMetric(sc.siteSyncLastRunStart, prometheus.GaugeValue, float64(run.lastStart), labels...)
+		ch <- prometheus.MustNewConstMetric(sc.siteSyncLastRunBytes, prometheus.GaugeValue, float64(run.lastBytes), labels...)
+	}
+	if run.lastFinish > 0 {
+		ch <- prometheus.MustNewConstMetric(sc.siteSyncLastRunFinish, prometheus.GaugeValue, float64(run.lastFinish), labels...)
+	}
+}
+
+// collectOutgoingMetrics emits metrics for syncs sending to remote sites,
+// including their snapshot queue depth and throttle.
+func (sc *SiteSyncCollector) collectOutgoingMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, siteMap map[int]string) error {
+	syncs, err := sc.Client().SiteSyncsOutgoing.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching outgoing site syncs: %w", err)
+	}
+
+	// Count queued snapshots per sync
+	queued := make(map[int]int)
+	queue, err := sc.Client().SiteSyncOutgoingQueue.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching outgoing site sync queue: %w", err)
+	}
+	for _, q := range queue {
+		queued[q.SiteSync]++
+	}
+
+	for _, s := range syncs {
+		if !sc.Included(s.Name) {
+			continue
+		}
+		labels := []string{systemName, s.Name, "outgoing", siteName(siteMap, s.Site)}
+
+		sc.emitRun(ch, siteSyncRun{
+			enabled:    s.Enabled,
+			status:     s.Status,
+			lastStart:  s.LastRunStart,
+			lastFinish: s.LastRunFinish,
+			lastBytes:  s.LastRunBytes,
+		}, labels)
+		ch <- prometheus.MustNewConstMetric(sc.siteSyncQueued, prometheus.GaugeValue, float64(queued[int(s.ID)]), labels...)
+		ch <- prometheus.MustNewConstMetric(sc.siteSyncThrottle, prometheus.GaugeValue, float64(s.Throttle), labels...)
+	}
+
+	return nil
+}
+
+// collectIncomingMetrics emits metrics for syncs receiving from remote sites.
+func (sc *SiteSyncCollector) collectIncomingMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, siteMap map[int]string) error {
+	syncs, err := sc.Client().SiteSyncsIncoming.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching incoming site syncs: %w", err)
+	}
+
+	for _, s := range syncs {
+		if !sc.Included(s.Name) {
+			continue
+		}
+		labels := []string{systemName, s.Name, "incoming", siteName(siteMap, s.Site)}
+
+		sc.emitRun(ch, siteSyncRun{
+			enabled:    s.Enabled,
+			status:     s.Status,
+			lastStart:  s.LastRunStart,
+			lastFinish: s.LastRunFinish,
+			lastBytes:  s.LastRunBytes,
+		}, labels)
+	}
+
+	return nil
+}
+
+// buildSiteMap fetches the remote sites and returns a map of site ID to name.
+func (sc *SiteSyncCollector) buildSiteMap(ctx context.Context) (map[int]string, error) {
+	sites, err := sc.Client().Sites.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	siteMap := make(map[int]string)
+	for _, site := range sites {
+		siteMap[int(site.ID)] = site.Name
+	}
+	return siteMap, nil
+}
+
+// siteName resolves a site ID, falling back to "site_<id>" for unknown sites.
+func siteName(siteMap map[int]string, id int) string {
+	if name := siteMap[id]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("site_%d", id)
+}
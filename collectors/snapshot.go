@@ -0,0 +1,280 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*SnapshotCollector)(nil)
+
+// snapshotFrequencies maps a snapshot profile period's frequency to how often
+// it takes a snapshot.
+var snapshotFrequencies = map[string]time.Duration{
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 31 * 24 * time.Hour,
+	"yearly":  366 * 24 * time.Hour,
+}
+
+// snapshotComplianceIntervals is how many of its profile's intervals a VM's
+// newest snapshot may be old before the VM is out of compliance. VergeOS has
+// no notion of compliance; this is the exporter's own policy, chosen so one
+// missed or late run is tolerated but a second is not.
+const snapshotComplianceIntervals = 2
+
+// cloudSnapshotPending holds the cloud snapshot statuses that do not yet, or
+// never will, give a restorable point. The API does not enumerate cloud
+// snapshot statuses, so this lists the in-progress and failure values the
+// exporter knows of, and any other status, including one added later, counts
+// as completed.
+var cloudSnapshotPending = map[string]bool{
+	"initializing": true,
+	"creating":     true,
+	"error":        true,
+	"failed":       true,
+}
+
+// SnapshotCollector collects per-VM snapshot and system-level cloud snapshot
+// metrics
+type SnapshotCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	// VM snapshot metrics
+	vmSnapshots             *prometheus.Desc
+	vmSnapshotNewestAge     *prometheus.Desc
+	vmSnapshotOldestAge     *prometheus.Desc
+	vmSnapshotProfileComply *prometheus.Desc
+
+	// Cloud snapshot metrics
+	cloudSnapshots           *prometheus.Desc
+	cloudSnapshotLastSuccess *prometheus.Desc
+	cloudSnapshotLastExpires *prometheus.Desc
+}
+
+// NewSnapshotCollector creates a new SnapshotCollector
+func NewSnapshotCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *SnapshotCollector {
+	vmLabels := []string{"system_name", "vm_name", "vm_id"}
+
+	return &SnapshotCollector{
+		BaseCollector: *NewBaseCollector("snapshot", client, scrapeTimeout, opts...),
+		vmSnapshots: prometheus.NewDesc(
+			"vergeos_vm_snapshots_total",
+			"Number of snapshots of the VM",
+			vmLabels,
+			nil,
+		),
+		vmSnapshotNewestAge: prometheus.NewDesc(
+			"vergeos_vm_snapshot_newest_age_seconds",
+			"Age of the VM's newest snapshot in seconds",
+			vmLabels,
+			nil,
+		),
+		vmSnapshotOldestAge: prometheus.NewDesc(
+			"vergeos_vm_snapshot_oldest_age_seconds",
+			"Age of the VM's oldest snapshot in seconds",
+			vmLabels,
+			nil,
+		),
+		vmSnapshotProfileComply: prometheus.NewDesc(
+			"vergeos_vm_snapshot_profile_compliant",
+			"Whether the VM's newest snapshot is recent enough for its snapshot profile (1=compliant, 0=overdue or no snapshot)",
+			[]string{"system_name", "vm_name", "vm_id", "profile"},
+			nil,
+		),
+		cloudSnapshots: prometheus.NewDesc(
+			"vergeos_cloud_snapshots_total",
+			"Number of cloud snapshots",
+			[]string{"system_name"},
+			nil,
+		),
+		cloudSnapshotLastSuccess: prometheus.NewDesc(
+			"vergeos_cloud_snapshot_last_success_timestamp_seconds",
+			"Unix time the newest completed cloud snapshot was taken",
+			[]string{"system_name"},
+			nil,
+		),
+		cloudSnapshotLastExpires: prometheus.NewDesc(
+			"vergeos_cloud_snapshot_last_success_expires_timestamp_seconds",
+			"Unix time the newest completed cloud snapshot expires (0=never)",
+			[]string{"system_name"},
+			nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (sc *SnapshotCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sc.vmSnapshots
+	ch <- sc.vmSnapshotNewestAge
+	ch <- sc.vmSnapshotOldestAge
+	ch <- sc.vmSnapshotProfileComply
+	ch <- sc.cloudSnapshots
+	ch <- sc.cloudSnapshotLastSuccess
+	ch <- sc.cloudSnapshotLastExpires
+
+	sc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (sc *SnapshotCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	sc.Run(ch, sc.collect)
+}
+
+func (sc *SnapshotCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := sc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	return errors.Join(
+		sc.collectVMSnapshotMetrics(ctx, ch, systemName),
+		sc.collectCloudSnapshotMetrics(ctx, ch, systemName),
+	)
+}
+
+// vmSnapshotSummary holds the snapshot count and age range of one machine.
+type vmSnapshotSummary struct {
+	count          int
+	newest, oldest int64
+}
+
+// collectVMSnapshotMetrics emits the snapshot count, age range and profile
+// compliance of every VM passing the name filter. VMs without snapshots report
+// a count of 0 and no ages.
+func (sc *SnapshotCollector) collectVMSnapshotMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) error {
+	vms, err := sc.ListVMs(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching VMs: %w", err)
+	}
+
+	snapshots, err := sc.ListMachineSnapshots(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching VM snapshots: %w", err)
+	}
+
+	// Summarise snapshots by machine ID
+	summaries := make(map[int]*vmSnapshotSummary)
+	for _, snap := range snapshots {
+		s, ok := summaries[snap.Machine]
+		if !ok {
+			s = &vmSnapshotSummary{newest: snap.Created, oldest: snap.Created}
+			summaries[snap.Machine] = s
+		}
+		s.count++
+		s.newest = max(s.newest, snap.Created)
+		s.oldest = min(s.oldest, snap.Created)
+	}
+
+	profiles, err := sc.buildProfileMap(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching snapshot profiles: %w", err)
+	}
+
+	now := time.Now()
+	for _, vm := range vms {
+		if !sc.Included(vm.Name) {
+			continue
+		}
+		labels := []string{systemName, vm.Name, fmt.Sprintf("%d", int(vm.ID))}
+
+		s := summaries[vm.Machine]
+		if s == nil {
+			s = &vmSnapshotSummary{}
+		}
+		ch <- prometheus.MustNewConstMetric(sc.vmSnapshots, prometheus.GaugeValue, float64(s.count), labels...)
+
+		var newestAge time.Duration
+		if s.count > 0 {
+			newestAge = now.Sub(time.Unix(s.newest, 0))
+			oldestAge := now.Sub(time.Unix(s.oldest, 0))
+			ch <- prometheus.MustNewConstMetric(sc.vmSnapshotNewestAge, prometheus.GaugeValue, newestAge.Seconds(), labels...)
+			ch <- prometheus.MustNewConstMetric(sc.vmSnapshotOldestAge, prometheus.GaugeValue, oldestAge.Seconds(), labels...)
+		}
+
+		// Compliance is only reported for VMs assigned a profile.
+		profile, ok := profiles[int(vm.SnapshotProfile)]
+		if !ok {
+			continue
+		}
+		compliant := s.count > 0 && profile.interval > 0 && newestAge <= snapshotComplianceIntervals*profile.interval
+		ch <- prometheus.MustNewConstMetric(sc.vmSnapshotProfileComply, prometheus.GaugeValue, boolToFloat64(compliant), append(labels, profile.name)...)
+	}
+
+	return nil
+}
+
+// snapshotProfile is a snapshot profile's name and the interval of its most
+// frequent period.
+type snapshotProfile struct {
+	name     string
+	interval time.Duration
+}
+
+// buildProfileMap fetches snapshot profiles and their periods and returns a
+// map of profile ID to snapshotProfile. A profile whose periods all have an
+// unknown frequency has a zero interval.
+func (sc *SnapshotCollector) buildProfileMap(ctx context.Context) (map[int]snapshotProfile, error) {
+	profiles, err := sc.Client().SnapshotProfiles.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	periods, err := sc.Client().SnapshotProfilePeriods.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profileMap := make(map[int]snapshotProfile)
+	for _, p := range profiles {
+		profileMap[int(p.ID)] = snapshotProfile{name: p.Name}
+	}
+	for _, period := range periods {
+		p, ok := profileMap[period.Profile]
+		if !ok {
+			continue
+		}
+		interval := snapshotFrequencies[period.Frequency]
+		if interval > 0 && (p.interval == 0 || interval < p.interval) {
+			p.interval = interval
+			profileMap[period.Profile] = p
+		}
+	}
+	return profileMap, nil
+}
+
+// collectCloudSnapshotMetrics emits the cloud snapshot count and the time and
+// expiry of the newest completed cloud snapshot, if any.
+func (sc *SnapshotCollector) collectCloudSnapshotMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) error {
+	snapshots, err := sc.Client().CloudSnapshots.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching cloud snapshots: %w", err)
+	}
+
+	ch <- prometheus.MustNewConstMetric(sc.cloudSnapshots, prometheus.GaugeValue, float64(len(snapshots)), systemName)
+
+	var last *vergeos.CloudSnapshot
+	for i := range snapshots {
+		if cloudSnapshotPending[snapshots[i].Status] {
+			continue
+		}
+		if last == nil || snapshots[i].Created > last.Created {
+			last = &snapshots[i]
+		}
+	}
+	if last != nil {
+		ch <- prometheus.MustNewConstMetric(sc.cloudSnapshotLastSuccess, prometheus.GaugeValue, float64(last.Created), systemName)
+		ch <- prometheus.MustNewConstMetric(sc.cloudSnapshotLastExpires, prometheus.GaugeValue, float64(last.Expires), systemName)
+	}
+
+	return nil
+}
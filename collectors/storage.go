@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -68,13 +69,13 @@ type StorageCollector struct {
 }
 
 // NewStorageCollector creates a new StorageCollector.
-func NewStorageCollector(client *vergeos.Client, scrapeTimeout time.Duration) *StorageCollector {
+func NewStorageCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *StorageCollector {
 	tierLabels := []string{"system_name", "tier", "description"}
 	tierStatusLabels := []string{"system_name", "tier", "status"}
 	driveLabels := []string{"system_name", "node_name", "drive_name", "tier", "serial"}
 
 	sc := &StorageCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("storage", client, scrapeTimeout, opts...),
 
 		// VSAN tier capacity metrics
 		vsanCapacity: prometheus.NewDesc(
@@ -303,6 +304,8 @@ func (sc *StorageCollector) Describe(ch chan<- *prometheus.Desc) {
 	// Online node/drive counts
 	ch <- sc.vsanNodesOnline
 	ch <- sc.vsanDrivesOnline
+
+	sc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -310,30 +313,30 @@ func (sc *StorageCollector) Collect(ch chan<- prometheus.Metric) {
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
 
-	ctx, cancel := sc.ScrapeContext()
-	defer cancel()
+	sc.Run(ch, sc.collect)
+}
 
+func (sc *StorageCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// Get system name using SDK (via BaseCollector)
 	systemName, err := sc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("Error getting system name: %v", err)
-		return
+		return err
 	}
 
-	// Collect VSAN tier metrics
-	sc.collectTierMetrics(ctx, ch, systemName)
-
-	// Collect drive metrics
-	sc.collectDriveMetrics(ctx, ch, systemName)
+	return errors.Join(
+		// Collect VSAN tier metrics
+		sc.collectTierMetrics(ctx, ch, systemName),
+		// Collect drive metrics
+		sc.collectDriveMetrics(ctx, ch, systemName),
+	)
 }
 
 // collectTierMetrics handles VSAN tier capacity and status metrics
-func (sc *StorageCollector) collectTierMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) {
+func (sc *StorageCollector) collectTierMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) error {
 	// Collect VSAN tier metrics using SDK
 	storageTiers, err := sc.Client().StorageTiers.List(ctx)
 	if err != nil {
-		log.Printf("Error fetching storage tiers: %v", err)
-		return
+		return fmt.Errorf("fetching storage tiers: %w", err)
 	}
 
 	// Build validTiers set for Bug #27 (phantom tiers fix)
@@ -373,8 +376,7 @@ func (sc *StorageCollector) collectTierMetrics(ctx context.Context, ch chan<- pr
 	// Get VSAN tier details using SDK
 	clusterTiers, err := sc.Client().ClusterTiers.List(ctx)
 	if err != nil {
-		log.Printf("Error fetching cluster tiers: %v", err)
-		return
+		return fmt.Errorf("fetching cluster tiers: %w", err)
 	}
 
 	// Process tier details
@@ -461,6 +463,8 @@ func (sc *StorageCollector) collectTierMetrics(ctx context.Context, ch chan<- pr
 			systemName, tierStr, status,
 		)
 	}
+
+	return nil
 }
 
 // driveStateKey is a key for grouping drives by tier and status
@@ -470,12 +474,11 @@ type driveStateKey struct {
 }
 
 // collectDriveMetrics handles per-drive I/O and hardware metrics
-func (sc *StorageCollector) collectDriveMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) {
+func (sc *StorageCollector) collectDriveMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string) error {
 	// Fetch all physical drives (now includes NodeDisplay and StatusList)
 	drives, err := sc.Client().MachineDrivePhys.List(ctx)
 	if err != nil {
-		log.Printf("Error fetching machine drive phys: %v", err)
-		return
+		return fmt.Errorf("fetching machine drive phys: %w", err)
 	}
 
 	// Fetch physical drive stats and build lookup map
@@ -584,6 +587,8 @@ func (sc *StorageCollector) collectDriveMetrics(ctx context.Context, ch chan<- p
 			systemName, key.Tier, key.Status,
 		)
 	}
+
+	return nil
 }
 
 // boolToFloat64 converts a boolean to 1.0 or 0.0 for Prometheus gauges.
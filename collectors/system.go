@@ -1,6 +1,8 @@
 package collectors
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
@@ -24,9 +26,9 @@ type SystemCollector struct {
 }
 
 // NewSystemCollector creates a new SystemCollector
-func NewSystemCollector(client *vergeos.Client, scrapeTimeout time.Duration) *SystemCollector {
+func NewSystemCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *SystemCollector {
 	return &SystemCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("system", client, scrapeTimeout, opts...),
 		systemVersion: prometheus.NewDesc(
 			"vergeos_system_version",
 			"Current version of the VergeOS system (always 1, version in label)",
@@ -60,6 +62,8 @@ func (sc *SystemCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- sc.systemInfo
 	ch <- sc.systemBranch
 	ch <- sc.systemVersionLatest
+
+	sc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -67,21 +71,22 @@ func (sc *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 	sc.mutex.Lock()
 	defer sc.mutex.Unlock()
 
-	ctx, cancel := sc.ScrapeContext()
-	defer cancel()
+	sc.Run(ch, sc.collect)
+}
 
+// collect needs the system info; the branch and latest available version
+// are best-effort.
+func (sc *SystemCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	// Get system name using BaseCollector (SDK)
 	systemName, err := sc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Get system info using SDK
 	info, err := sc.client.System.GetInfo(ctx)
 	if err != nil {
-		log.Printf("Error getting system info: %v", err)
-		return
+		return fmt.Errorf("getting system info: %w", err)
 	}
 
 	// Emit system version metric
@@ -137,4 +142,6 @@ func (sc *SystemCollector) Collect(ch chan<- prometheus.Metric) {
 		1.0,
 		systemName, info.Version, latestVersion, branchName, info.Hash,
 	)
+
+	return nil
 }
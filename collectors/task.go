@@ -0,0 +1,139 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*TaskCollector)(nil)
+
+// taskFailedStatuses holds the task statuses that count as a failure.
+var taskFailedStatuses = map[string]bool{
+	"error":  true,
+	"failed": true,
+}
+
+// TaskCollector collects metrics about long-running VergeOS tasks such as
+// updates, imports and migrations
+type TaskCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	tasksRunning *prometheus.Desc
+	taskProgress *prometheus.Desc
+	taskAge      *prometheus.Desc
+	tasksFailed  *prometheus.Desc
+
+	// failedSeen maps each failed task's ID to the time it finished, so a
+	// failure is counted once, and a task that fails again is counted again.
+	// It is nil until the first successful scrape, whose failures predate the
+	// collector and are not counted.
+	failedSeen map[int]int64
+	failed     map[string]float64
+}
+
+// NewTaskCollector creates a new TaskCollector
+func NewTaskCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *TaskCollector {
+	taskLabels := []string{"system_name", "task_id", "task_name", "type"}
+
+	return &TaskCollector{
+		BaseCollector: *NewBaseCollector("task", client, scrapeTimeout, opts...),
+		tasksRunning: prometheus.NewDesc(
+			"vergeos_tasks_running",
+			"Number of running tasks by type",
+			[]string{"system_name", "type"},
+			nil,
+		),
+		taskProgress: prometheus.NewDesc(
+			"vergeos_task_progress_pct",
+			"Progress of the running task as a percentage",
+			taskLabels,
+			nil,
+		),
+		taskAge: prometheus.NewDesc(
+			"vergeos_task_age_seconds",
+			"Seconds since the running task started; the exporter does not flag stuck tasks, alert on age and stalled progress instead",
+			taskLabels,
+			nil,
+		),
+		tasksFailed: prometheus.NewDesc(
+			"vergeos_tasks_failed_total",
+			"Number of tasks that failed since the exporter started, by type",
+			[]string{"system_name", "type"},
+			nil,
+		),
+		failed: make(map[string]float64),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (tc *TaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tc.tasksRunning
+	ch <- tc.taskProgress
+	ch <- tc.taskAge
+	ch <- tc.tasksFailed
+
+	tc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (tc *TaskCollector) Collect(ch chan<- prometheus.Metric) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+
+	tc.Run(ch, tc.collect)
+}
+
+func (tc *TaskCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := tc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := tc.Client().Tasks.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching tasks: %w", err)
+	}
+
+	now := time.Now()
+	running := make(map[string]int)
+	failedNow := make(map[int]int64)
+	for _, task := range tasks {
+		switch {
+		case task.Status == "running":
+			running[task.Type]++
+			labels := []string{systemName, fmt.Sprintf("%d", int(task.ID)), task.Name, task.Type}
+			ch <- prometheus.MustNewConstMetric(tc.taskProgress, prometheus.GaugeValue, task.Progress, labels...)
+			if task.Started > 0 {
+				age := now.Sub(time.Unix(task.Started, 0))
+				ch <- prometheus.MustNewConstMetric(tc.taskAge, prometheus.GaugeValue, age.Seconds(), labels...)
+			}
+
+		case taskFailedStatuses[task.Status]:
+			id := int(task.ID)
+			failedNow[id] = task.Finished
+			if tc.failedSeen == nil {
+				continue
+			}
+			if finished, ok := tc.failedSeen[id]; !ok || finished != task.Finished {
+				tc.failed[task.Type]++
+			}
+		}
+	}
+	// Tasks no longer listed are dropped, so the map doesn't grow forever.
+	tc.failedSeen = failedNow
+
+	for taskType, n := range running {
+		ch <- prometheus.MustNewConstMetric(tc.tasksRunning, prometheus.GaugeValue, float64(n), systemName, taskType)
+	}
+	for taskType, n := range tc.failed {
+		ch <- prometheus.MustNewConstMetric(tc.tasksFailed, prometheus.CounterValue, n, systemName, taskType)
+	}
+
+	return nil
+}
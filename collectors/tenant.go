@@ -2,6 +2,7 @@ package collectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
@@ -57,14 +58,14 @@ type TenantCollector struct {
 }
 
 // NewTenantCollector creates a new TenantCollector.
-func NewTenantCollector(client *vergeos.Client, scrapeTimeout time.Duration) *TenantCollector {
+func NewTenantCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *TenantCollector {
 	tenantLabels := []string{"system_name", "tenant_name"}
 	tenantNodeLabels := []string{"system_name", "tenant_name", "node_name"}
 	tenantStorageLabels := []string{"system_name", "tenant_name", "tier"}
 	tenantStatusLabels := []string{"system_name", "tenant_name", "status"}
 
 	return &TenantCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("tenant", client, scrapeTimeout, opts...),
 
 		// Tenant-level metrics
 		tenantsTotal: prometheus.NewDesc(
@@ -241,6 +242,8 @@ func (tc *TenantCollector) Describe(ch chan<- *prometheus.Desc) {
 
 	// Tenant network
 	ch <- tc.tenantL2NetworksTotal
+
+	tc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector.
@@ -248,19 +251,19 @@ func (tc *TenantCollector) Collect(ch chan<- prometheus.Metric) {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
-	ctx, cancel := tc.ScrapeContext()
-	defer cancel()
+	tc.Run(ch, tc.collect)
+}
 
+func (tc *TenantCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	systemName, err := tc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Build tenant map (id -> name) and emit total count
-	tenantMap, tenantCount := tc.buildTenantMap(ctx)
-	if tenantMap == nil {
-		return
+	tenantMap, tenantCount, err := tc.buildTenantMap(ctx)
+	if err != nil {
+		return err
 	}
 
 	ch <- prometheus.MustNewConstMetric(
@@ -269,42 +272,41 @@ func (tc *TenantCollector) Collect(ch chan<- prometheus.Metric) {
 		systemName,
 	)
 
-	// Collect tenant status metrics
-	tc.collectTenantStatusMetrics(ctx, ch, systemName, tenantMap)
-
 	// Collect tenant aggregate stats (CPU, RAM, IP, GPU from TenantStatsHistoryShort)
 	tc.collectTenantStatsMetrics(ctx, ch, systemName, tenantMap)
 
-	// Collect tenant node metrics
-	tc.collectTenantNodeMetrics(ctx, ch, systemName, tenantMap)
-
-	// Collect tenant storage metrics
-	tc.collectTenantStorageMetrics(ctx, ch, systemName, tenantMap)
-
-	// Collect tenant network metrics
-	tc.collectTenantNetworkMetrics(ctx, ch, systemName, tenantMap)
+	return errors.Join(
+		// Collect tenant status metrics
+		tc.collectTenantStatusMetrics(ctx, ch, systemName, tenantMap),
+		// Collect tenant node metrics
+		tc.collectTenantNodeMetrics(ctx, ch, systemName, tenantMap),
+		// Collect tenant storage metrics
+		tc.collectTenantStorageMetrics(ctx, ch, systemName, tenantMap),
+		// Collect tenant network metrics
+		tc.collectTenantNetworkMetrics(ctx, ch, systemName, tenantMap),
+	)
 }
 
-// buildTenantMap fetches tenants and builds a map of tenant ID to name.
-// Returns nil map on error. Filters out snapshot tenants.
-func (tc *TenantCollector) buildTenantMap(ctx context.Context) (map[int]string, int) {
+// buildTenantMap fetches tenants and builds a map of tenant ID to name,
+// returning the map and its size. Filters out snapshot tenants and tenants excluded
+// by the name filter, so every per-tenant metric below honours it.
+func (tc *TenantCollector) buildTenantMap(ctx context.Context) (map[int]string, int, error) {
 	tenants, err := tc.Client().Tenants.List(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error fetching tenants: %v", err)
-		return nil, 0
+		return nil, 0, fmt.Errorf("fetching tenants: %w", err)
 	}
 
 	tenantMap := make(map[int]string)
 	count := 0
 	for _, t := range tenants {
-		if t.IsSnapshot {
+		if t.IsSnapshot || !tc.Included(t.Name) {
 			continue
 		}
 		tenantMap[int(t.Key)] = t.Name
 		count++
 	}
 
-	return tenantMap, count
+	return tenantMap, count, nil
 }
 
 // tenantName resolves a tenant ID to its name using the map, with fallback.
@@ -316,11 +318,10 @@ func tenantName(tenantMap map[int]string, tenantID int) string {
 }
 
 // collectTenantStatusMetrics emits running and status metrics per tenant.
-func (tc *TenantCollector) collectTenantStatusMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) {
+func (tc *TenantCollector) collectTenantStatusMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) error {
 	statuses, err := tc.Client().TenantStatus.List(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error fetching tenant statuses: %v", err)
-		return
+		return fmt.Errorf("fetching tenant statuses: %w", err)
 	}
 
 	for _, s := range statuses {
@@ -345,89 +346,101 @@ func (tc *TenantCollector) collectTenantStatusMetrics(ctx context.Context, ch ch
 			)
 		}
 	}
+
+	return nil
 }
 
 // collectTenantStatsMetrics emits aggregate CPU/RAM/IP/GPU metrics per tenant
-// using TenantStatsHistoryShort.GetLatest().
+// using TenantStatsHistoryShort.GetLatest(). The SDK issues one request per
+// tenant, so they run concurrently within the collector's limiter.
 func (tc *TenantCollector) collectTenantStatsMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) {
-	for tenantID, name := range tenantMap {
-		stats, err := tc.Client().TenantStatsHistoryShort.GetLatest(ctx, tenantID)
-		if err != nil {
-			if vergeos.IsNotFoundError(err) {
-				// Offline tenants may not have stats
-				continue
-			}
-			log.Printf("TenantCollector: Error fetching stats for tenant %s: %v", name, err)
-			continue
+	tenantIDs := make([]int, 0, len(tenantMap))
+	for tenantID := range tenantMap {
+		tenantIDs = append(tenantIDs, tenantID)
+	}
+
+	tc.FanOut(ctx, len(tenantIDs), func(i int) {
+		tc.collectTenantStats(ctx, ch, systemName, tenantIDs[i], tenantMap[tenantIDs[i]])
+	})
+}
+
+// collectTenantStats emits the aggregate metrics of one tenant.
+func (tc *TenantCollector) collectTenantStats(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantID int, name string) {
+	stats, err := tc.Client().TenantStatsHistoryShort.GetLatest(ctx, tenantID)
+	if err != nil {
+		if vergeos.IsNotFoundError(err) {
+			// Offline tenants may not have stats
+			return
 		}
+		log.Printf("TenantCollector: Error fetching stats for tenant %s: %v", name, err)
+		return
+	}
 
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantCPUUsagePct, prometheus.GaugeValue,
+		float64(stats.TotalCPU),
+		systemName, name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantCPUCores, prometheus.GaugeValue,
+		float64(stats.CoreCount),
+		systemName, name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantRAMUsedBytes, prometheus.GaugeValue,
+		float64(stats.RAMUsed)*1048576,
+		systemName, name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantRAMAllocBytes, prometheus.GaugeValue,
+		float64(stats.RAMAllocated)*1048576,
+		systemName, name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantRAMUsagePct, prometheus.GaugeValue,
+		float64(stats.RAMPct),
+		systemName, name,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		tc.tenantIPCount, prometheus.GaugeValue,
+		float64(stats.IPCount),
+		systemName, name,
+	)
+
+	// GPU metrics — only emit when GPU resources exist
+	if stats.VGPUsTotal > 0 || stats.GPUsTotal > 0 {
 		ch <- prometheus.MustNewConstMetric(
-			tc.tenantCPUUsagePct, prometheus.GaugeValue,
-			float64(stats.TotalCPU),
-			systemName, name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			tc.tenantCPUCores, prometheus.GaugeValue,
-			float64(stats.CoreCount),
-			systemName, name,
-		)
-		ch <- prometheus.MustNewConstMetric(
-			tc.tenantRAMUsedBytes, prometheus.GaugeValue,
-			float64(stats.RAMUsed)*1048576,
+			tc.tenantVGPUsUsed, prometheus.GaugeValue,
+			float64(stats.VGPUsUsed),
 			systemName, name,
 		)
 		ch <- prometheus.MustNewConstMetric(
-			tc.tenantRAMAllocBytes, prometheus.GaugeValue,
-			float64(stats.RAMAllocated)*1048576,
+			tc.tenantVGPUsTotal, prometheus.GaugeValue,
+			float64(stats.VGPUsTotal),
 			systemName, name,
 		)
 		ch <- prometheus.MustNewConstMetric(
-			tc.tenantRAMUsagePct, prometheus.GaugeValue,
-			float64(stats.RAMPct),
+			tc.tenantGPUsUsed, prometheus.GaugeValue,
+			float64(stats.GPUsUsed),
 			systemName, name,
 		)
 		ch <- prometheus.MustNewConstMetric(
-			tc.tenantIPCount, prometheus.GaugeValue,
-			float64(stats.IPCount),
+			tc.tenantGPUsTotal, prometheus.GaugeValue,
+			float64(stats.GPUsTotal),
 			systemName, name,
 		)
-
-		// GPU metrics — only emit when GPU resources exist
-		if stats.VGPUsTotal > 0 || stats.GPUsTotal > 0 {
-			ch <- prometheus.MustNewConstMetric(
-				tc.tenantVGPUsUsed, prometheus.GaugeValue,
-				float64(stats.VGPUsUsed),
-				systemName, name,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				tc.tenantVGPUsTotal, prometheus.GaugeValue,
-				float64(stats.VGPUsTotal),
-				systemName, name,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				tc.tenantGPUsUsed, prometheus.GaugeValue,
-				float64(stats.GPUsUsed),
-				systemName, name,
-			)
-			ch <- prometheus.MustNewConstMetric(
-				tc.tenantGPUsTotal, prometheus.GaugeValue,
-				float64(stats.GPUsTotal),
-				systemName, name,
-			)
-		}
 	}
 }
 
 // collectTenantNodeMetrics emits per-node allocation and runtime metrics.
-func (tc *TenantCollector) collectTenantNodeMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) {
+func (tc *TenantCollector) collectTenantNodeMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) error {
 	nodes, err := tc.Client().TenantNodes.List(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error fetching tenant nodes: %v", err)
-		return
+		return fmt.Errorf("fetching tenant nodes: %w", err)
 	}
 
 	// Batch-fetch machine statuses and stats (avoids N+1 per-node API calls)
-	allStatuses, err := tc.Client().MachineStatus.List(ctx)
+	allStatuses, err := tc.ListMachineStatus(ctx)
 	if err != nil {
 		log.Printf("TenantCollector: Error batch-fetching machine statuses: %v", err)
 	}
@@ -436,7 +449,7 @@ func (tc *TenantCollector) collectTenantNodeMetrics(ctx context.Context, ch chan
 		statusMap[allStatuses[i].Machine] = &allStatuses[i]
 	}
 
-	allStats, err := tc.Client().MachineStats.List(ctx)
+	allStats, err := tc.ListMachineStats(ctx)
 	if err != nil {
 		log.Printf("TenantCollector: Error batch-fetching machine stats: %v", err)
 	}
@@ -518,14 +531,15 @@ func (tc *TenantCollector) collectTenantNodeMetrics(ctx context.Context, ch chan
 			systemName, tenantName(tenantMap, tid),
 		)
 	}
+
+	return nil
 }
 
 // collectTenantStorageMetrics emits per-tier storage allocation metrics.
-func (tc *TenantCollector) collectTenantStorageMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) {
+func (tc *TenantCollector) collectTenantStorageMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) error {
 	storage, err := tc.Client().TenantStorage.List(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error fetching tenant storage: %v", err)
-		return
+		return fmt.Errorf("fetching tenant storage: %w", err)
 	}
 
 	for _, s := range storage {
@@ -558,14 +572,15 @@ func (tc *TenantCollector) collectTenantStorageMetrics(ctx context.Context, ch c
 			systemName, tName, tierStr,
 		)
 	}
+
+	return nil
 }
 
 // collectTenantNetworkMetrics emits L2 network count per tenant.
-func (tc *TenantCollector) collectTenantNetworkMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) {
+func (tc *TenantCollector) collectTenantNetworkMetrics(ctx context.Context, ch chan<- prometheus.Metric, systemName string, tenantMap map[int]string) error {
 	networks, err := tc.Client().TenantLayer2Networks.List(ctx)
 	if err != nil {
-		log.Printf("TenantCollector: Error fetching tenant L2 networks: %v", err)
-		return
+		return fmt.Errorf("fetching tenant L2 networks: %w", err)
 	}
 
 	// Count networks per tenant
@@ -585,4 +600,6 @@ func (tc *TenantCollector) collectTenantNetworkMetrics(ctx context.Context, ch c
 			systemName, tenantName(tenantMap, tid),
 		)
 	}
+
+	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,12 +15,53 @@ import (
 
 var _ prometheus.Collector = (*VMCollector)(nil)
 
+// vmInfoLabels maps each label vergeos_vm_info can carry to its value for a
+// VM. tags is not here: tags live in their own table (see buildTagMap).
+var vmInfoLabels = map[string]func(vm vergeos.VM) string{
+	"machine_type": func(vm vergeos.VM) string { return vm.MachineType },
+	"os_family":    func(vm vergeos.VM) string { return vm.OSFamily },
+	"ha_group":     func(vm vergeos.VM) string { return vm.HAGroup },
+	"boot_order":   func(vm vergeos.VM) string { return vm.BootOrder },
+	"cpu_type":     func(vm vergeos.VM) string { return vm.CPUType },
+	"uefi":         func(vm vergeos.VM) string { return fmt.Sprintf("%t", vm.UEFI) },
+	"secure_boot":  func(vm vergeos.VM) string { return fmt.Sprintf("%t", vm.SecureBoot) },
+	"ballooning":   func(vm vergeos.VM) string { return fmt.Sprintf("%t", vm.Balloon) },
+	"description":  func(vm vergeos.VM) string { return vm.Description },
+}
+
+// VMInfoLabels lists every label vergeos_vm_info supports, for validating
+// the configured allow-list.
+var VMInfoLabels = []string{
+	"machine_type", "os_family", "ha_group", "boot_order", "cpu_type",
+	"uefi", "secure_boot", "ballooning", "description", "tags",
+}
+
+// defaultVMInfoLabels are the vergeos_vm_info labels used unless configured
+// otherwise. description and tags are free-form, so they are opt-in.
+var defaultVMInfoLabels = []string{
+	"machine_type", "os_family", "ha_group", "boot_order", "cpu_type",
+	"uefi", "secure_boot", "ballooning",
+}
+
 // vmStatus holds resolved status info for a VM's machine
 type vmStatus struct {
 	NodeName string
 	Running  bool
 }
 
+// vmPlacement is where a VM was last seen running and how often it has moved.
+type vmPlacement struct {
+	node        string // last node the VM ran on; kept while it is powered off
+	running     bool   // running at the previous scrape
+	nodeChanges float64
+	migrations  float64
+}
+
+// nodePair is the source and destination node of a live migration.
+type nodePair struct {
+	source, destination string
+}
+
 // VMCollector collects per-VM metrics
 type VMCollector struct {
 	BaseCollector
@@ -55,16 +98,47 @@ type VMCollector struct {
 	vmDiskWriteBytes  *prometheus.Desc
 	vmDiskUtil        *prometheus.Desc
 	vmDiskServiceTime *prometheus.Desc
+
+	// Storage rollup metrics
+	vmStorageUsedBytes *prometheus.Desc
+
+	// Inventory metrics
+	vmInfo     *prometheus.Desc
+	infoLabels []string
+
+	// Guest agent metrics
+	vmGuestInfo           *prometheus.Desc
+	vmGuestUptime         *prometheus.Desc
+	vmGuestIPAddress      *prometheus.Desc
+	vmGuestFilesystemSize *prometheus.Desc
+	vmGuestFilesystemFree *prometheus.Desc
+
+	// Placement history metrics
+	vmNodeChanges       *prometheus.Desc
+	vmMigrations        *prometheus.Desc
+	vmMigrationsByNodes *prometheus.Desc
+
+	// Placement history, derived by comparing each scrape's node with the
+	// previous one and kept between scrapes. placements is keyed by VM ID.
+	placements       map[int]vmPlacement
+	migrationsByNode map[nodePair]float64
 }
 
 // NewVMCollector creates a new VMCollector
-func NewVMCollector(client *vergeos.Client, scrapeTimeout time.Duration) *VMCollector {
+func NewVMCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *VMCollector {
 	vmLabels := []string{"system_name", "cluster", "node", "vm_name", "vm_id"}
 	nicLabels := []string{"system_name", "cluster", "node", "vm_name", "vm_id", "nic_name"}
-	diskLabels := []string{"system_name", "cluster", "node", "vm_name", "vm_id", "disk_name", "interface", "media"}
+	diskLabels := []string{"system_name", "cluster", "node", "vm_name", "vm_id", "disk_name", "interface", "media", "tier"}
+	fsLabels := []string{"system_name", "cluster", "node", "vm_name", "vm_id", "mountpoint", "fs_type", "fs_index"}
+	// Counters and rollups have no node label, which would change with every
+	// move.
+	stableLabels := []string{"system_name", "cluster", "vm_name", "vm_id"}
+
+	base := NewBaseCollector("vm", client, scrapeTimeout, opts...)
+	infoLabels := base.InfoLabels(defaultVMInfoLabels)
 
 	return &VMCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *base,
 		vmCPUTotal: prometheus.NewDesc(
 			"vergeos_vm_cpu_total",
 			"Total CPU usage percentage",
@@ -173,6 +247,69 @@ func NewVMCollector(client *vergeos.Client, scrapeTimeout time.Duration) *VMColl
 			diskLabels,
 			nil,
 		),
+		vmStorageUsedBytes: prometheus.NewDesc(
+			"vergeos_vm_storage_used_bytes",
+			"Used bytes of the VM's disks (source=disks) or of its snapshots' disks (source=snapshots) on the storage tier",
+			append(stableLabels, "tier", "source"),
+			nil,
+		),
+		vmInfo: prometheus.NewDesc(
+			"vergeos_vm_info",
+			"VM configuration (always 1)",
+			append(stableLabels, infoLabels...),
+			nil,
+		),
+		infoLabels: infoLabels,
+		vmGuestInfo: prometheus.NewDesc(
+			"vergeos_vm_guest_info",
+			"Guest OS reported by the VM's guest agent (always 1)",
+			append(vmLabels, "os_name", "os_version", "agent_version"),
+			nil,
+		),
+		vmGuestUptime: prometheus.NewDesc(
+			"vergeos_vm_guest_uptime_seconds",
+			"Guest OS uptime reported by the VM's guest agent",
+			vmLabels,
+			nil,
+		),
+		vmGuestIPAddress: prometheus.NewDesc(
+			"vergeos_vm_guest_ip_address_info",
+			"IP address reported by the VM's guest agent (always 1)",
+			append(vmLabels, "interface", "address"),
+			nil,
+		),
+		vmGuestFilesystemSize: prometheus.NewDesc(
+			"vergeos_vm_guest_filesystem_size_bytes",
+			"Guest filesystem size in bytes reported by the VM's guest agent",
+			fsLabels,
+			nil,
+		),
+		vmGuestFilesystemFree: prometheus.NewDesc(
+			"vergeos_vm_guest_filesystem_free_bytes",
+			"Guest filesystem free space in bytes reported by the VM's guest agent",
+			fsLabels,
+			nil,
+		),
+		vmNodeChanges: prometheus.NewDesc(
+			"vergeos_vm_node_changes_total",
+			"Times the VM was seen on a different node than before, including restarts elsewhere",
+			stableLabels,
+			nil,
+		),
+		vmMigrations: prometheus.NewDesc(
+			"vergeos_vm_migrations_total",
+			"Times the VM moved node while running (live migrations)",
+			stableLabels,
+			nil,
+		),
+		vmMigrationsByNodes: prometheus.NewDesc(
+			"vergeos_vm_node_migrations_total",
+			"Live migrations from the source node to the destination node",
+			[]string{"system_name", "source_node", "destination_node"},
+			nil,
+		),
+		placements:       make(map[int]vmPlacement),
+		migrationsByNode: make(map[nodePair]float64),
 	}
 }
 
@@ -196,6 +333,18 @@ func (vc *VMCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- vc.vmDiskWriteBytes
 	ch <- vc.vmDiskUtil
 	ch <- vc.vmDiskServiceTime
+	ch <- vc.vmStorageUsedBytes
+	ch <- vc.vmInfo
+	ch <- vc.vmGuestInfo
+	ch <- vc.vmGuestUptime
+	ch <- vc.vmGuestIPAddress
+	ch <- vc.vmGuestFilesystemSize
+	ch <- vc.vmGuestFilesystemFree
+	ch <- vc.vmNodeChanges
+	ch <- vc.vmMigrations
+	ch <- vc.vmMigrationsByNodes
+
+	vc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector
@@ -203,41 +352,40 @@ func (vc *VMCollector) Collect(ch chan<- prometheus.Metric) {
 	vc.mutex.Lock()
 	defer vc.mutex.Unlock()
 
-	ctx, cancel := vc.ScrapeContext()
-	defer cancel()
+	vc.Run(ch, vc.collect)
+}
 
+// collect needs the VMs with their machine stats and status; NICs, disks,
+// snapshots, guest agent info and tags are best-effort and only drop their
+// own series.
+func (vc *VMCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	systemName, err := vc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	// Build cluster ID → name mapping
 	clusterMap, err := vc.BuildClusterMap(ctx)
 	if err != nil {
-		log.Printf("Error building cluster map: %v", err)
-		return
+		return fmt.Errorf("building cluster map: %w", err)
 	}
 
 	// Fetch all non-snapshot VMs
-	vms, err := vc.Client().VMs.List(ctx, vergeos.WithFilter("is_snapshot eq false"))
+	vms, err := vc.ListVMs(ctx)
 	if err != nil {
-		log.Printf("Error fetching VMs: %v", err)
-		return
+		return fmt.Errorf("fetching VMs: %w", err)
 	}
 
 	// Batch fetch machine stats → map[machineID]*MachineStats
 	statsMap, err := vc.buildStatsMap(ctx)
 	if err != nil {
-		log.Printf("Error fetching machine stats: %v", err)
-		return
+		return fmt.Errorf("fetching machine stats: %w", err)
 	}
 
 	// Batch fetch machine status
 	statusMap, err := vc.buildStatusMap(ctx)
 	if err != nil {
-		log.Printf("Error fetching machine status: %v", err)
-		return
+		return fmt.Errorf("fetching machine status: %w", err)
 	}
 
 	// Batch fetch all NIC stats
@@ -260,7 +408,32 @@ func (vc *VMCollector) Collect(ch chan<- prometheus.Metric) {
 		// Non-fatal: continue without disk I/O metrics
 	}
 
+	snapMachineMap, err := vc.buildSnapshotMachineMap(ctx)
+	if err != nil {
+		log.Printf("Error fetching VM snapshots: %v", err)
+		// Non-fatal: continue without snapshot storage usage
+	}
+
+	guestMap, err := vc.buildGuestInfoMap(ctx)
+	if err != nil {
+		log.Printf("Error fetching VM guest agent info: %v", err)
+		// Non-fatal: continue without guest metrics
+	}
+
+	// Tags are only fetched when vergeos_vm_info carries them
+	var tagMap map[int]string
+	if slices.Contains(vc.infoLabels, "tags") {
+		if tagMap, err = vc.buildTagMap(ctx); err != nil {
+			log.Printf("Error fetching VM tags: %v", err)
+			// Non-fatal: continue with empty tags
+		}
+	}
+
+	placements := make(map[int]vmPlacement, len(vms))
 	for _, vm := range vms {
+		if !vc.Included(vm.Name) {
+			continue
+		}
 		vmID := fmt.Sprintf("%d", int(vm.ID))
 
 		// Resolve cluster name
@@ -293,6 +466,36 @@ func (vc *VMCollector) Collect(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(vc.vmCPUTotal, prometheus.GaugeValue, totalCPU, labels...)
 		ch <- prometheus.MustNewConstMetric(vc.vmRAMUsedBytes, prometheus.GaugeValue, ramUsedBytes, labels...)
 
+		stableLabels := []string{systemName, clusterName, vm.Name, vmID}
+
+		// Inventory
+		infoValues := append([]string{}, stableLabels...)
+		for _, name := range vc.infoLabels {
+			if name == "tags" {
+				infoValues = append(infoValues, tagMap[int(vm.ID)])
+				continue
+			}
+			infoValues = append(infoValues, vmInfoLabels[name](vm))
+		}
+		ch <- prometheus.MustNewConstMetric(vc.vmInfo, prometheus.GaugeValue, 1.0, infoValues...)
+
+		// Placement history
+		placement := vc.updatePlacement(int(vm.ID), status)
+		placements[int(vm.ID)] = placement
+		ch <- prometheus.MustNewConstMetric(vc.vmNodeChanges, prometheus.CounterValue, placement.nodeChanges, stableLabels...)
+		ch <- prometheus.MustNewConstMetric(vc.vmMigrations, prometheus.CounterValue, placement.migrations, stableLabels...)
+
+		// Storage used by tier, for the VM's own disks and its snapshots'
+		var snapDisks []vergeos.VMDrive
+		for _, snapMachine := range snapMachineMap[vm.Machine] {
+			snapDisks = append(snapDisks, diskMap[snapMachine]...)
+		}
+		for source, disks := range map[string][]vergeos.VMDrive{"disks": diskMap[vm.Machine], "snapshots": snapDisks} {
+			for tier, used := range usedBytesByTier(disks) {
+				ch <- prometheus.MustNewConstMetric(vc.vmStorageUsedBytes, prometheus.GaugeValue, used, append(stableLabels, tier, source)...)
+			}
+		}
+
 		// NIC metrics (only for VMs with NICs)
 		if nics, ok := nicMap[vm.Machine]; ok {
 			for _, nic := range nics {
@@ -307,10 +510,15 @@ func (vc *VMCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 
+		// Guest agent metrics (only for VMs whose agent has reported)
+		if guest, ok := guestMap[vm.Machine]; ok {
+			vc.collectGuestMetrics(ch, guest, labels)
+		}
+
 		// Disk metrics (only for VMs with drives)
 		if disks, ok := diskMap[vm.Machine]; ok {
 			for _, disk := range disks {
-				diskLabels := append(labels, disk.Name, disk.Interface, disk.Media)
+				diskLabels := append(labels, disk.Name, disk.Interface, disk.Media, disk.PreferredTier)
 
 				// Config: size and used
 				ch <- prometheus.MustNewConstMetric(vc.vmDiskSizeBytes, prometheus.GaugeValue, float64(disk.SizeBytes), diskLabels...)
@@ -328,11 +536,40 @@ func (vc *VMCollector) Collect(ch chan<- prometheus.Metric) {
 			}
 		}
 	}
+
+	// VMs no longer listed are dropped, so their history doesn't accumulate.
+	vc.placements = placements
+	for pair, n := range vc.migrationsByNode {
+		ch <- prometheus.MustNewConstMetric(vc.vmMigrationsByNodes, prometheus.CounterValue, n, systemName, pair.source, pair.destination)
+	}
+
+	return nil
+}
+
+// updatePlacement compares the VM's node in this scrape with the node it was
+// last seen on and returns its updated placement. A move while running at
+// both scrapes is a live migration; any other move (such as an HA restart
+// after its node failed) is only a node change. The first sighting of a VM
+// records where it is without counting a move.
+func (vc *VMCollector) updatePlacement(vmID int, status vmStatus) vmPlacement {
+	p, seen := vc.placements[vmID]
+	if seen && status.NodeName != "" && p.node != "" && p.node != status.NodeName {
+		p.nodeChanges++
+		if p.running && status.Running {
+			p.migrations++
+			vc.migrationsByNode[nodePair{p.node, status.NodeName}]++
+		}
+	}
+	if status.NodeName != "" {
+		p.node = status.NodeName
+	}
+	p.running = status.Running
+	return p
 }
 
 // buildStatsMap batch-fetches all machine stats and returns a map keyed by machine ID
 func (vc *VMCollector) buildStatsMap(ctx context.Context) (map[int]*vergeos.MachineStats, error) {
-	allStats, err := vc.Client().MachineStats.List(ctx)
+	allStats, err := vc.ListMachineStats(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list machine stats: %w", err)
 	}
@@ -346,7 +583,7 @@ func (vc *VMCollector) buildStatsMap(ctx context.Context) (map[int]*vergeos.Mach
 
 // buildStatusMap batch-fetches machine statuses and returns a map of machine ID → vmStatus.
 func (vc *VMCollector) buildStatusMap(ctx context.Context) (map[int]vmStatus, error) {
-	statuses, err := vc.Client().MachineStatus.List(ctx)
+	statuses, err := vc.ListMachineStatus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list machine status: %w", err)
 	}
@@ -363,7 +600,7 @@ func (vc *VMCollector) buildStatusMap(ctx context.Context) (map[int]vmStatus, er
 
 // buildNICMap batch-fetches all machine NICs and returns a map of machine ID → []MachineNIC.
 func (vc *VMCollector) buildNICMap(ctx context.Context) (map[int][]vergeos.MachineNIC, error) {
-	allNICs, err := vc.Client().MachineNICs.List(ctx)
+	allNICs, err := vc.ListMachineNICs(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list machine NICs: %w", err)
 	}
@@ -404,3 +641,112 @@ func (vc *VMCollector) buildDiskStatsMap(ctx context.Context) (map[int]*vergeos.
 	}
 	return statsMap, nil
 }
+
+// buildSnapshotMachineMap lists VM snapshots and returns a map of each VM's
+// machine ID to the machine IDs holding its snapshots.
+func (vc *VMCollector) buildSnapshotMachineMap(ctx context.Context) (map[int][]int, error) {
+	snapshots, err := vc.ListMachineSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VM snapshots: %w", err)
+	}
+
+	snapMachineMap := make(map[int][]int)
+	for _, snap := range snapshots {
+		if snap.SnapMachine != 0 {
+			snapMachineMap[snap.Machine] = append(snapMachineMap[snap.Machine], snap.SnapMachine)
+		}
+	}
+	return snapMachineMap, nil
+}
+
+// usedBytesByTier sums the used bytes of disks by preferred storage tier.
+func usedBytesByTier(disks []vergeos.VMDrive) map[string]float64 {
+	used := make(map[string]float64)
+	for _, disk := range disks {
+		used[disk.PreferredTier] += float64(disk.UsedBytes)
+	}
+	return used
+}
+
+// buildGuestInfoMap batch-fetches guest agent info and returns a map of
+// machine ID → MachineGuestInfo. Machines without a running agent are absent.
+func (vc *VMCollector) buildGuestInfoMap(ctx context.Context) (map[int]*vergeos.MachineGuestInfo, error) {
+	allInfo, err := vc.Client().MachineGuestInfo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list guest agent info: %w", err)
+	}
+
+	guestMap := make(map[int]*vergeos.MachineGuestInfo)
+	for i := range allInfo {
+		guestMap[allInfo[i].Machine] = &allInfo[i]
+	}
+	return guestMap, nil
+}
+
+// collectGuestMetrics emits what the VM's guest agent reported. Uptime,
+// addresses and filesystems are each emitted only when the agent provides them.
+func (vc *VMCollector) collectGuestMetrics(ch chan<- prometheus.Metric, guest *vergeos.MachineGuestInfo, labels []string) {
+	ch <- prometheus.MustNewConstMetric(vc.vmGuestInfo, prometheus.GaugeValue, 1.0,
+		append(labels, guest.OSName, guest.OSVersion, guest.AgentVersion)...)
+
+	if guest.Uptime > 0 {
+		ch <- prometheus.MustNewConstMetric(vc.vmGuestUptime, prometheus.GaugeValue, float64(guest.Uptime), labels...)
+	}
+
+	// Agents can list the same address twice; the info series carries no
+	// value, so one series per interface and address is enough
+	seen := make(map[[2]string]bool, len(guest.IPAddresses))
+	for _, ip := range guest.IPAddresses {
+		key := [2]string{ip.Interface, ip.Address}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ch <- prometheus.MustNewConstMetric(vc.vmGuestIPAddress, prometheus.GaugeValue, 1.0,
+			append(labels, ip.Interface, ip.Address)...)
+	}
+
+	// Stacked mounts and volumes without a drive letter can share a
+	// mountpoint and type, so the position in the agent's list tells them apart
+	for i, fs := range guest.Filesystems {
+		fsLabels := append(labels, fs.Mountpoint, fs.Type, fmt.Sprintf("%d", i))
+		ch <- prometheus.MustNewConstMetric(vc.vmGuestFilesystemSize, prometheus.GaugeValue, float64(fs.TotalBytes), fsLabels...)
+		ch <- prometheus.MustNewConstMetric(vc.vmGuestFilesystemFree, prometheus.GaugeValue, float64(fs.FreeBytes), fsLabels...)
+	}
+}
+
+// buildTagMap fetches tag memberships and returns a map of VM ID to the
+// sorted, comma-separated names of its tags.
+func (vc *VMCollector) buildTagMap(ctx context.Context) (map[int]string, error) {
+	tags, err := vc.Client().Tags.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	tagNames := make(map[int]string, len(tags))
+	for _, tag := range tags {
+		tagNames[int(tag.ID)] = tag.Name
+	}
+
+	members, err := vc.Client().TagMembers.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tag members: %w", err)
+	}
+	// Members reference the tagged object as "<table>/<key>"
+	byVM := make(map[int][]string)
+	for _, m := range members {
+		var vmID int
+		if _, err := fmt.Sscanf(m.Member, "vms/%d", &vmID); err != nil {
+			continue
+		}
+		if name := tagNames[m.Tag]; name != "" {
+			byVM[vmID] = append(byVM[vmID], name)
+		}
+	}
+
+	tagMap := make(map[int]string, len(byVM))
+	for vmID, names := range byVM {
+		slices.Sort(names)
+		tagMap[vmID] = strings.Join(names, ",")
+	}
+	return tagMap, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/netip"
 	"strconv"
 	"sync"
 	"time"
@@ -16,7 +17,8 @@ var _ prometheus.Collector = (*VNetCollector)(nil)
 
 // VNetCollector collects metrics about VergeOS virtual networks (VNets):
 // inventory/config state for every network, plus the latest gateway-monitoring
-// stats for networks with gateway monitoring enabled.
+// stats for networks with gateway monitoring enabled, and the DHCP, DNS and
+// firewall services each network's router provides.
 type VNetCollector struct {
 	BaseCollector
 	mutex sync.Mutex
@@ -32,6 +34,15 @@ type VNetCollector struct {
 	vnetTxPackets *prometheus.Desc
 	vnetRxPackets *prometheus.Desc
 
+	// Router service metrics
+	vnetDHCPEnabled  *prometheus.Desc
+	vnetDHCPPoolSize *prometheus.Desc
+	vnetDHCPLeases   *prometheus.Desc
+	vnetDNSEnabled   *prometheus.Desc
+	vnetRuleEnabled  *prometheus.Desc
+	vnetRulePackets  *prometheus.Desc
+	vnetRuleBytes    *prometheus.Desc
+
 	// Gateway monitoring metrics (latest sample per monitored network)
 	monitorSent             *prometheus.Desc
 	monitorQuality          *prometheus.Desc
@@ -47,11 +58,12 @@ type VNetCollector struct {
 }
 
 // NewVNetCollector creates a new VNetCollector.
-func NewVNetCollector(client *vergeos.Client, scrapeTimeout time.Duration) *VNetCollector {
+func NewVNetCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *VNetCollector {
 	labels := []string{"system_name", "vnet_name", "vnet_id", "cluster", "type", "layer2_type"}
+	ruleLabels := append(labels, "rule_name", "direction")
 
 	return &VNetCollector{
-		BaseCollector: *NewBaseCollector(client, scrapeTimeout),
+		BaseCollector: *NewBaseCollector("vnet", client, scrapeTimeout, opts...),
 		vnetEnabled: prometheus.NewDesc(
 			"vergeos_vnet_enabled",
 			"Whether the virtual network is enabled (1=enabled, 0=disabled)",
@@ -87,6 +99,41 @@ func NewVNetCollector(client *vergeos.Client, scrapeTimeout time.Duration) *VNet
 			"Whether gateway monitoring is enabled for the virtual network (1=enabled, 0=disabled)",
 			labels, nil,
 		),
+		vnetDHCPEnabled: prometheus.NewDesc(
+			"vergeos_vnet_dhcp_enabled",
+			"Whether the virtual network's router serves DHCP (1=enabled, 0=disabled)",
+			labels, nil,
+		),
+		vnetDHCPPoolSize: prometheus.NewDesc(
+			"vergeos_vnet_dhcp_pool_size",
+			"Number of addresses in the virtual network's dynamic DHCP range",
+			labels, nil,
+		),
+		vnetDHCPLeases: prometheus.NewDesc(
+			"vergeos_vnet_dhcp_leases",
+			"Number of active dynamic DHCP leases on the virtual network",
+			labels, nil,
+		),
+		vnetDNSEnabled: prometheus.NewDesc(
+			"vergeos_vnet_dns_enabled",
+			"Whether the virtual network's router serves DNS (1=enabled, 0=disabled), by DNS mode",
+			append(labels, "dns_mode"), nil,
+		),
+		vnetRuleEnabled: prometheus.NewDesc(
+			"vergeos_vnet_firewall_rule_enabled",
+			"Whether the firewall rule is enabled (1=enabled, 0=disabled)",
+			ruleLabels, nil,
+		),
+		vnetRulePackets: prometheus.NewDesc(
+			"vergeos_vnet_firewall_rule_packets_total",
+			"Total packets matched by the firewall rule",
+			ruleLabels, nil,
+		),
+		vnetRuleBytes: prometheus.NewDesc(
+			"vergeos_vnet_firewall_rule_bytes_total",
+			"Total bytes matched by the firewall rule",
+			ruleLabels, nil,
+		),
 		monitorSent: prometheus.NewDesc(
 			"vergeos_vnet_monitor_sent",
 			"Monitoring packets sent in the latest gateway-monitoring sample",
@@ -154,6 +201,13 @@ func (vc *VNetCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- vc.vnetRxBytes
 	ch <- vc.vnetTxPackets
 	ch <- vc.vnetRxPackets
+	ch <- vc.vnetDHCPEnabled
+	ch <- vc.vnetDHCPPoolSize
+	ch <- vc.vnetDHCPLeases
+	ch <- vc.vnetDNSEnabled
+	ch <- vc.vnetRuleEnabled
+	ch <- vc.vnetRulePackets
+	ch <- vc.vnetRuleBytes
 	ch <- vc.monitorSent
 	ch <- vc.monitorQuality
 	ch <- vc.monitorDroppedPct
@@ -165,6 +219,8 @@ func (vc *VNetCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- vc.monitorBadChecksums
 	ch <- vc.monitorBadData
 	ch <- vc.monitorTimestampSeconds
+
+	vc.DescribeScrape(ch)
 }
 
 // Collect implements prometheus.Collector.
@@ -172,32 +228,30 @@ func (vc *VNetCollector) Collect(ch chan<- prometheus.Metric) {
 	vc.mutex.Lock()
 	defer vc.mutex.Unlock()
 
-	ctx, cancel := vc.ScrapeContext()
-	defer cancel()
+	vc.Run(ch, vc.collect)
+}
 
+func (vc *VNetCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
 	systemName, err := vc.GetSystemName(ctx)
 	if err != nil {
-		log.Printf("VNetCollector: Error getting system name: %v", err)
-		return
+		return err
 	}
 
 	clusterMap, err := vc.BuildClusterMap(ctx)
 	if err != nil {
-		log.Printf("VNetCollector: Error building cluster map: %v", err)
-		return
+		return fmt.Errorf("building cluster map: %w", err)
 	}
 
 	networks, err := vc.Client().Networks.List(ctx)
 	if err != nil {
-		log.Printf("VNetCollector: Error fetching networks: %v", err)
-		return
+		return fmt.Errorf("fetching networks: %w", err)
 	}
 
 	// Map NIC key -> NIC so each vnet's router NIC stats can be joined
 	// without per-vnet API calls. Best-effort: a NIC fetch failure only
 	// drops the traffic counters, not the rest of the VNet metrics.
 	nicByKey := make(map[int]vergeos.MachineNIC)
-	if allNICs, err := vc.Client().MachineNICs.List(ctx); err != nil {
+	if allNICs, err := vc.ListMachineNICs(ctx); err != nil {
 		log.Printf("VNetCollector: Error fetching NICs (traffic counters skipped): %v", err)
 	} else {
 		for _, nic := range allNICs {
@@ -205,6 +259,26 @@ func (vc *VNetCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 
+	// Batch-fetch leases and firewall rules for every network. Best-effort,
+	// like the NIC stats: a failure only drops the lease count or rules.
+	var leases map[int]int
+	if addresses, err := vc.Client().NetworkAddresses.List(ctx, vergeos.WithFilter("type eq 'dynamic'")); err != nil {
+		log.Printf("VNetCollector: Error fetching DHCP leases (lease counts skipped): %v", err)
+	} else {
+		leases = make(map[int]int)
+		for _, addr := range addresses {
+			leases[addr.Network]++
+		}
+	}
+	rules, err := vc.Client().NetworkRules.List(ctx)
+	if err != nil {
+		log.Printf("VNetCollector: Error fetching firewall rules (rule counters skipped): %v", err)
+	}
+	rulesByNetwork := make(map[int][]vergeos.NetworkRule)
+	for _, rule := range rules {
+		rulesByNetwork[rule.Network] = append(rulesByNetwork[rule.Network], rule)
+	}
+
 	type monitoredVNet struct {
 		id     int
 		name   string
@@ -213,14 +287,10 @@ func (vc *VNetCollector) Collect(ch chan<- prometheus.Metric) {
 	var monitored []monitoredVNet
 
 	for _, network := range networks {
-		clusterName := clusterMap[int(network.Cluster)]
-		if clusterName == "" {
-			clusterName = fmt.Sprintf("cluster_%d", network.Cluster)
-		}
-		labels := []string{
-			systemName, network.Name, strconv.Itoa(int(network.ID)),
-			clusterName, network.Type, network.Layer2Type,
+		if !vc.Included(network.Name) {
+			continue
 		}
+		labels := vnetLabels(systemName, network, clusterMap)
 
 		ch <- prometheus.MustNewConstMetric(
 			vc.vnetEnabled, prometheus.GaugeValue,
@@ -253,6 +323,8 @@ func (vc *VNetCollector) Collect(ch chan<- prometheus.Metric) {
 			)
 		}
 
+		vc.collectServiceMetrics(ch, network, leases, rulesByNetwork[int(network.ID)], labels)
+
 		if network.MonitorGateway {
 			monitored = append(monitored, monitoredVNet{id: int(network.ID), name: network.Name, labels: labels})
 		}
@@ -261,18 +333,101 @@ func (vc *VNetCollector) Collect(ch chan<- prometheus.Metric) {
 	// Fetch latest monitor stats with bounded concurrency so many monitored
 	// networks don't serially exhaust the scrape timeout (the SDK issues one
 	// HTTP request per network).
-	var wg sync.WaitGroup
-	sem := make(chan struct{}, 8)
-	for _, m := range monitored {
-		wg.Add(1)
-		go func(m monitoredVNet) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			vc.collectMonitorStats(ctx, ch, m.id, m.name, m.labels)
-		}(m)
+	vc.FanOut(ctx, len(monitored), func(i int) {
+		m := monitored[i]
+		vc.collectMonitorStats(ctx, ch, m.id, m.name, m.labels)
+	})
+
+	return nil
+}
+
+// vnetLabels returns the label values identifying a network, shared by the
+// collectors reporting per-network metrics.
+func vnetLabels(systemName string, network vergeos.Network, clusterMap map[int]string) []string {
+	clusterName := clusterMap[int(network.Cluster)]
+	if clusterName == "" {
+		clusterName = fmt.Sprintf("cluster_%d", network.Cluster)
+	}
+	return []string{
+		systemName, network.Name, strconv.Itoa(int(network.ID)),
+		clusterName, network.Type, network.Layer2Type,
+	}
+}
+
+// ruleKey identifies the firewall rules sharing one set of rule series.
+type ruleKey struct {
+	name, direction string
+}
+
+// ruleCounters are the summed state of the rules sharing a ruleKey.
+type ruleCounters struct {
+	enabled        bool
+	packets, bytes float64
+}
+
+// collectServiceMetrics emits the DHCP, DNS and firewall metrics for one
+// network. leases is nil when the lease fetch failed, in which case the lease
+// count is omitted rather than reported as 0.
+func (vc *VNetCollector) collectServiceMetrics(ch chan<- prometheus.Metric, network vergeos.Network, leases map[int]int, rules []vergeos.NetworkRule, labels []string) {
+	ch <- prometheus.MustNewConstMetric(
+		vc.vnetDHCPEnabled, prometheus.GaugeValue,
+		boolToFloat64(network.DHCPEnabled), labels...,
+	)
+	if network.DHCPEnabled {
+		if size, ok := dhcpPoolSize(network.DHCPStart, network.DHCPStop); ok {
+			ch <- prometheus.MustNewConstMetric(
+				vc.vnetDHCPPoolSize, prometheus.GaugeValue, float64(size), labels...,
+			)
+		}
+		if leases != nil {
+			ch <- prometheus.MustNewConstMetric(
+				vc.vnetDHCPLeases, prometheus.GaugeValue, float64(leases[int(network.ID)]), labels...,
+			)
+		}
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		vc.vnetDNSEnabled, prometheus.GaugeValue,
+		boolToFloat64(network.DNS != "" && network.DNS != "disabled"), append(labels, network.DNS)...,
+	)
+
+	// Rules with the same name and direction are summed into one series, so
+	// duplicate names don't collide.
+	counters := make(map[ruleKey]*ruleCounters)
+	for _, rule := range rules {
+		key := ruleKey{rule.Name, rule.Direction}
+		c, ok := counters[key]
+		if !ok {
+			c = &ruleCounters{}
+			counters[key] = c
+		}
+		c.enabled = c.enabled || rule.Enabled
+		c.packets += float64(rule.Packets)
+		c.bytes += float64(rule.Bytes)
+	}
+	for key, c := range counters {
+		ruleLabels := append(labels, key.name, key.direction)
+		ch <- prometheus.MustNewConstMetric(vc.vnetRuleEnabled, prometheus.GaugeValue, boolToFloat64(c.enabled), ruleLabels...)
+		ch <- prometheus.MustNewConstMetric(vc.vnetRulePackets, prometheus.CounterValue, c.packets, ruleLabels...)
+		ch <- prometheus.MustNewConstMetric(vc.vnetRuleBytes, prometheus.CounterValue, c.bytes, ruleLabels...)
+	}
+}
+
+// dhcpPoolSize returns the number of addresses from start to stop inclusive.
+// It reports false unless both are IPv4 addresses with start <= stop.
+func dhcpPoolSize(start, stop string) (uint64, bool) {
+	from, err := netip.ParseAddr(start)
+	if err != nil || !from.Is4() {
+		return 0, false
+	}
+	to, err := netip.ParseAddr(stop)
+	if err != nil || !to.Is4() || to.Less(from) {
+		return 0, false
 	}
-	wg.Wait()
+	f, t := from.As4(), to.As4()
+	first := uint64(f[0])<<24 | uint64(f[1])<<16 | uint64(f[2])<<8 | uint64(f[3])
+	last := uint64(t[0])<<24 | uint64(t[1])<<16 | uint64(t[2])<<8 | uint64(t[3])
+	return last - first + 1, true
 }
 
 // collectMonitorStats emits the latest gateway-monitoring stats for one network.
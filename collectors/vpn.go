@@ -0,0 +1,221 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+var _ prometheus.Collector = (*VPNCollector)(nil)
+
+// wireGuardHandshakeTimeout is how old a WireGuard peer's latest handshake
+// may be for the peer to count as up. WireGuard renews the session every two
+// minutes while traffic flows, so a handshake older than this means the
+// tunnel is idle or broken.
+const wireGuardHandshakeTimeout = 180 * time.Second
+
+// VPNCollector collects per-tunnel metrics for the IPsec connections and
+// WireGuard peers of VergeOS virtual networks
+type VPNCollector struct {
+	BaseCollector
+	mutex sync.Mutex
+
+	vpnUp           *prometheus.Desc
+	vpnHandshakeAge *prometheus.Desc
+	vpnRxBytes      *prometheus.Desc
+	vpnTxBytes      *prometheus.Desc
+	vpnTunnelsTotal *prometheus.Desc
+	vpnTunnelsUp    *prometheus.Desc
+}
+
+// NewVPNCollector creates a new VPNCollector
+func NewVPNCollector(client *vergeos.Client, scrapeTimeout time.Duration, opts ...Option) *VPNCollector {
+	vnetLabelNames := []string{"system_name", "vnet_name", "vnet_id", "cluster", "type", "layer2_type"}
+	tunnelLabels := append(vnetLabelNames, "protocol", "tunnel", "peer")
+
+	return &VPNCollector{
+		BaseCollector: *NewBaseCollector("vpn", client, scrapeTimeout, opts...),
+		vpnUp: prometheus.NewDesc(
+			"vergeos_vpn_tunnel_up",
+			"Whether the VPN tunnel to the peer is up (1=up, 0=down)",
+			tunnelLabels, nil,
+		),
+		vpnHandshakeAge: prometheus.NewDesc(
+			"vergeos_vpn_last_handshake_age_seconds",
+			"Seconds since the tunnel's latest successful handshake or IPsec rekey",
+			tunnelLabels, nil,
+		),
+		vpnRxBytes: prometheus.NewDesc(
+			"vergeos_vpn_rx_bytes_total",
+			"Total bytes received through the tunnel",
+			tunnelLabels, nil,
+		),
+		vpnTxBytes: prometheus.NewDesc(
+			"vergeos_vpn_tx_bytes_total",
+			"Total bytes transmitted through the tunnel",
+			tunnelLabels, nil,
+		),
+		vpnTunnelsTotal: prometheus.NewDesc(
+			"vergeos_vpn_tunnels_total",
+			"Number of VPN tunnels by protocol",
+			[]string{"system_name", "protocol"}, nil,
+		),
+		vpnTunnelsUp: prometheus.NewDesc(
+			"vergeos_vpn_tunnels_up",
+			"Number of VPN tunnels that are up by protocol",
+			[]string{"system_name", "protocol"}, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (vc *VPNCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- vc.vpnUp
+	ch <- vc.vpnHandshakeAge
+	ch <- vc.vpnRxBytes
+	ch <- vc.vpnTxBytes
+	ch <- vc.vpnTunnelsTotal
+	ch <- vc.vpnTunnelsUp
+
+	vc.DescribeScrape(ch)
+}
+
+// Collect implements prometheus.Collector
+func (vc *VPNCollector) Collect(ch chan<- prometheus.Metric) {
+	vc.mutex.Lock()
+	defer vc.mutex.Unlock()
+
+	vc.Run(ch, vc.collect)
+}
+
+// vpnTunnel is one IPsec connection or WireGuard peer, in common form.
+type vpnTunnel struct {
+	up               bool
+	lastHandshake    int64
+	rxBytes, txBytes int64
+}
+
+func (vc *VPNCollector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	systemName, err := vc.GetSystemName(ctx)
+	if err != nil {
+		return err
+	}
+
+	clusterMap, err := vc.BuildClusterMap(ctx)
+	if err != nil {
+		return fmt.Errorf("building cluster map: %w", err)
+	}
+
+	networks, err := vc.Client().Networks.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching networks: %w", err)
+	}
+	netLabels := make(map[int][]string, len(networks))
+	for _, network := range networks {
+		if vc.Included(network.Name) {
+			netLabels[int(network.ID)] = vnetLabels(systemName, network, clusterMap)
+		}
+	}
+
+	now := time.Now()
+	totals := make(map[string]int)
+	ups := make(map[string]int)
+	emit := func(protocol, name, peer string, labels []string, t vpnTunnel) {
+		totals[protocol]++
+		if t.up {
+			ups[protocol]++
+		}
+		tunnelLabels := append(append([]string{}, labels...), protocol, name, peer)
+		ch <- prometheus.MustNewConstMetric(vc.vpnUp, prometheus.GaugeValue, boolToFloat64(t.up), tunnelLabels...)
+		if t.lastHandshake > 0 {
+			age := now.Sub(time.Unix(t.lastHandshake, 0))
+			ch <- prometheus.MustNewConstMetric(vc.vpnHandshakeAge, prometheus.GaugeValue, age.Seconds(), tunnelLabels...)
+		}
+		ch <- prometheus.MustNewConstMetric(vc.vpnRxBytes, prometheus.CounterValue, float64(t.rxBytes), tunnelLabels...)
+		ch <- prometheus.MustNewConstMetric(vc.vpnTxBytes, prometheus.CounterValue, float64(t.txBytes), tunnelLabels...)
+	}
+
+	errs := map[string]error{
+		"ipsec":     vc.collectIPsec(ctx, netLabels, emit),
+		"wireguard": vc.collectWireGuard(ctx, netLabels, now, emit),
+	}
+
+	// A protocol whose fetch failed reports no totals, rather than 0.
+	for protocol, err := range errs {
+		if err != nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(vc.vpnTunnelsTotal, prometheus.GaugeValue, float64(totals[protocol]), systemName, protocol)
+		ch <- prometheus.MustNewConstMetric(vc.vpnTunnelsUp, prometheus.GaugeValue, float64(ups[protocol]), systemName, protocol)
+	}
+
+	return errors.Join(errs["ipsec"], errs["wireguard"])
+}
+
+// collectIPsec reports each IPsec connection as a tunnel to its remote
+// gateway. A connection is up while its security association is established.
+func (vc *VPNCollector) collectIPsec(ctx context.Context, netLabels map[int][]string, emit func(protocol, name, peer string, labels []string, t vpnTunnel)) error {
+	conns, err := vc.Client().NetworkIPsecConnections.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching IPsec connections: %w", err)
+	}
+
+	for _, c := range conns {
+		labels, ok := netLabels[c.Network]
+		if !ok {
+			continue
+		}
+		emit("ipsec", c.Name, c.RemoteGateway, labels, vpnTunnel{
+			up:            c.Established,
+			lastHandshake: c.EstablishedAt,
+			rxBytes:       c.RxBytes,
+			txBytes:       c.TxBytes,
+		})
+	}
+	return nil
+}
+
+// collectWireGuard reports each WireGuard peer as a tunnel of its interface.
+// A peer is up while its latest handshake is recent (see
+// wireGuardHandshakeTimeout).
+func (vc *VPNCollector) collectWireGuard(ctx context.Context, netLabels map[int][]string, now time.Time, emit func(protocol, name, peer string, labels []string, t vpnTunnel)) error {
+	interfaces, err := vc.Client().NetworkWireGuards.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching WireGuard interfaces: %w", err)
+	}
+	peers, err := vc.Client().NetworkWireGuardPeers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching WireGuard peers: %w", err)
+	}
+
+	type wgInterface struct {
+		name   string
+		labels []string
+	}
+	ifaceMap := make(map[int]wgInterface, len(interfaces))
+	for _, iface := range interfaces {
+		if labels, ok := netLabels[iface.Network]; ok {
+			ifaceMap[int(iface.ID)] = wgInterface{iface.Name, labels}
+		}
+	}
+
+	for _, p := range peers {
+		iface, ok := ifaceMap[p.WireGuard]
+		if !ok {
+			continue
+		}
+		up := p.LastHandshake > 0 && now.Sub(time.Unix(p.LastHandshake, 0)) <= wireGuardHandshakeTimeout
+		emit("wireguard", iface.name, p.Name, iface.labels, vpnTunnel{
+			up:            up,
+			lastHandshake: p.LastHandshake,
+			rxBytes:       p.RxBytes,
+			txBytes:       p.TxBytes,
+		})
+	}
+	return nil
+}
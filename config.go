@@ -0,0 +1,422 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+
+	"vergeos-exporter/collectors"
+)
+
+// defaultModule is the probe module used when a /probe request omits ?module=.
+const defaultModule = "default"
+
+// labelNameRE matches valid Prometheus label names.
+var labelNameRE = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// filterableCollectors are the collectors that honour a name filter, mapped to
+// the kind of object the filter matches against.
+var filterableCollectors = map[string]string{
+	"node":     "node name",
+	"network":  "node name",
+	"tenant":   "tenant name",
+	"vm":       "VM name",
+	"vnet":     "virtual network name",
+	"snapshot": "VM name",
+	"sitesync": "site sync name",
+	"vpn":      "virtual network name",
+	"routing":  "virtual network name",
+}
+
+// infoLabelCollectors are the collectors with an info metric whose labels can
+// be chosen, mapped to the labels they support.
+var infoLabelCollectors = map[string][]string{
+	"vm": collectors.VMInfoLabels,
+}
+
+// config is the file loaded from -config.file. The verge, scrape, collectors,
+// labels, filters and info_labels sections configure the cloud served on the
+// metrics path; targets and modules name the clouds and collector sets /probe
+// may scrape.
+// Command-line flags and VERGE_* environment variables override the file.
+type config struct {
+	Verge      targetConfig            `yaml:"verge"`
+	Scrape     scrapeConfig            `yaml:"scrape"`
+	Collectors map[string]bool         `yaml:"collectors"`
+	Labels     map[string]string       `yaml:"labels"`
+	Filters    map[string]filterConfig `yaml:"filters"`
+	InfoLabels map[string][]string     `yaml:"info_labels"`
+	Targets    map[string]targetConfig `yaml:"targets"`
+	Modules    map[string]moduleConfig `yaml:"modules"`
+
+	// nameFilters holds Filters compiled by validate.
+	nameFilters map[string]collectors.NameFilter
+}
+
+// scrapeConfig holds scrape settings. A non-zero PollInterval switches every
+// collector on the metrics path to background polling; PollIntervals sets it
+// per collector and overrides PollInterval. Concurrency bounds the per-object
+// API requests in flight against each cloud, /probe targets included.
+type scrapeConfig struct {
+	Timeout       time.Duration            `yaml:"timeout"`
+	Concurrency   int                      `yaml:"concurrency"`
+	PollInterval  time.Duration            `yaml:"poll_interval"`
+	PollIntervals map[string]time.Duration `yaml:"poll_intervals"`
+}
+
+// filterConfig restricts a collector to objects whose names match Include and
+// do not match Exclude. Both are regular expressions and both are optional.
+type filterConfig struct {
+	Include string `yaml:"include"`
+	Exclude string `yaml:"exclude"`
+}
+
+// targetConfig holds the connection settings for one named VergeOS cloud.
+// PasswordFile and APIKeyFile name files holding the secret instead, such as
+// Docker or Kubernetes secret mounts; they are watched and a change reloads
+// the exporter.
+type targetConfig struct {
+	URL          string `yaml:"url"`
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+	APIKey       string `yaml:"api_key"`
+	APIKeyFile   string `yaml:"api_key_file"`
+	Insecure     bool   `yaml:"insecure"`
+}
+
+// withSecrets returns t with Password and APIKey read from their files.
+func (t targetConfig) withSecrets() (targetConfig, error) {
+	var err error
+	if t.PasswordFile != "" {
+		if t.Password != "" {
+			return t, fmt.Errorf("password and password_file are mutually exclusive")
+		}
+		if t.Password, err = readSecretFile(t.PasswordFile); err != nil {
+			return t, err
+		}
+	}
+	if t.APIKeyFile != "" {
+		if t.APIKey != "" {
+			return t, fmt.Errorf("api_key and api_key_file are mutually exclusive")
+		}
+		if t.APIKey, err = readSecretFile(t.APIKeyFile); err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+// readSecretFile returns the contents of a credential file without
+// surrounding whitespace, so a trailing newline isn't part of the secret.
+func readSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read credential file: %w", err)
+	}
+	secret := strings.TrimSpace(string(b))
+	if secret == "" {
+		return "", fmt.Errorf("credential file %s is empty", path)
+	}
+	return secret, nil
+}
+
+// moduleConfig selects which collectors a probe runs and how long it may take.
+// An empty Collectors list means every collector that is on by default; a zero
+// Timeout falls back to -scrape.timeout.
+type moduleConfig struct {
+	Collectors []string      `yaml:"collectors"`
+	Timeout    time.Duration `yaml:"timeout"`
+}
+
+// loadConfig reads and validates the config file at path. Unknown keys are
+// rejected so typos surface at startup rather than as silently ignored settings.
+func loadConfig(path string) (*config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	cfg := &config{}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// validate checks every section, compiles filters, and fills in the implicit
+// default module.
+func (c *config) validate() error {
+	// The local cloud's credential files are read again when the exporter is
+	// built, alongside any given on the command line.
+	verge, err := c.Verge.withSecrets()
+	if err != nil {
+		return fmt.Errorf("verge: %w", err)
+	}
+	if verge.APIKey != "" || verge.Username != "" || verge.Password != "" {
+		if _, err := authOption(verge.APIKey, verge.Username, verge.Password); err != nil {
+			return fmt.Errorf("verge: %w", err)
+		}
+	}
+	if c.Scrape.Timeout < 0 {
+		return fmt.Errorf("scrape: timeout must not be negative")
+	}
+	if c.Scrape.Concurrency < 0 {
+		return fmt.Errorf("scrape: concurrency must not be negative")
+	}
+	if c.Scrape.PollInterval < 0 {
+		return fmt.Errorf("scrape: poll_interval must not be negative")
+	}
+	for name, d := range c.Scrape.PollIntervals {
+		if _, ok := collectorFactories[name]; !ok {
+			return fmt.Errorf("scrape: poll_intervals: unknown collector %q", name)
+		}
+		if d < 0 {
+			return fmt.Errorf("scrape: poll_intervals: %s must not be negative", name)
+		}
+	}
+	for name := range c.Collectors {
+		if _, ok := collectorFactories[name]; !ok {
+			return fmt.Errorf("collectors: unknown collector %q", name)
+		}
+	}
+	for name := range c.Labels {
+		if !labelNameRE.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("labels: invalid label name %q", name)
+		}
+	}
+
+	c.nameFilters = make(map[string]collectors.NameFilter)
+	for name, f := range c.Filters {
+		if _, ok := filterableCollectors[name]; !ok {
+			return fmt.Errorf("filters: collector %q does not support filtering", name)
+		}
+		var nf collectors.NameFilter
+		if f.Include != "" {
+			if nf.Include, err = regexp.Compile(f.Include); err != nil {
+				return fmt.Errorf("filters: %s include: %w", name, err)
+			}
+		}
+		if f.Exclude != "" {
+			if nf.Exclude, err = regexp.Compile(f.Exclude); err != nil {
+				return fmt.Errorf("filters: %s exclude: %w", name, err)
+			}
+		}
+		c.nameFilters[name] = nf
+	}
+
+	for name, labels := range c.InfoLabels {
+		supported, ok := infoLabelCollectors[name]
+		if !ok {
+			return fmt.Errorf("info_labels: collector %q has no info metric", name)
+		}
+		seen := make(map[string]bool, len(labels))
+		for _, label := range labels {
+			if !slices.Contains(supported, label) {
+				return fmt.Errorf("info_labels: %s: unknown label %q", name, label)
+			}
+			if seen[label] {
+				return fmt.Errorf("info_labels: %s: duplicate label %q", name, label)
+			}
+			seen[label] = true
+		}
+	}
+
+	for name, t := range c.Targets {
+		if t.URL == "" {
+			return fmt.Errorf("target %q: url is required", name)
+		}
+		if t, err = t.withSecrets(); err != nil {
+			return fmt.Errorf("target %q: %w", name, err)
+		}
+		if _, err := authOption(t.APIKey, t.Username, t.Password); err != nil {
+			return fmt.Errorf("target %q: %w", name, err)
+		}
+		c.Targets[name] = t
+	}
+
+	if c.Modules == nil {
+		c.Modules = make(map[string]moduleConfig)
+	}
+	if _, ok := c.Modules[defaultModule]; !ok {
+		c.Modules[defaultModule] = moduleConfig{}
+	}
+	for name, m := range c.Modules {
+		seen := make(map[string]bool, len(m.Collectors))
+		for _, collector := range m.Collectors {
+			if _, ok := collectorFactories[collector]; !ok {
+				return fmt.Errorf("module %q: unknown collector %q", name, collector)
+			}
+			if seen[collector] {
+				return fmt.Errorf("module %q: duplicate collector %q", name, collector)
+			}
+			seen[collector] = true
+		}
+		if m.Timeout < 0 {
+			return fmt.Errorf("module %q: timeout must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// settings are the effective options for the cloud served on the metrics
+// path, after merging the config file underneath the command line.
+type settings struct {
+	url           string
+	username      string
+	password      string
+	passwordFile  string
+	apiKey        string
+	apiKeyFile    string
+	insecure      bool
+	scrapeTimeout time.Duration
+	concurrency   int
+	readyMaxAge   time.Duration
+	pollIntervals map[string]time.Duration
+	collectors    []string
+	labels        prometheus.Labels
+	filters       map[string]collectors.NameFilter
+	infoLabels    map[string][]string
+}
+
+// hasCredentials reports whether any credential is configured for the local
+// cloud. Without one, a config file with targets runs the exporter probe-only.
+func (s settings) hasCredentials() bool {
+	return s.apiKey != "" || s.username != "" || s.password != "" || s.passwordFile != "" || s.apiKeyFile != ""
+}
+
+// readSecretFiles fills the password and API key from their files, if set.
+func (s *settings) readSecretFiles() error {
+	t, err := targetConfig{
+		Password: s.password, PasswordFile: s.passwordFile,
+		APIKey: s.apiKey, APIKeyFile: s.apiKeyFile,
+	}.withSecrets()
+	if err != nil {
+		return err
+	}
+	s.password, s.apiKey = t.Password, t.APIKey
+	return nil
+}
+
+// resolveSettings merges cfg (which may be nil) with the flags. A flag given
+// on the command line or through its VERGE_* variable wins over the file;
+// credentials are taken as a unit so a file API key never mixes with a
+// command-line username.
+func resolveSettings(cfg *config) settings {
+	s := settings{
+		url:           *vergeURL,
+		username:      *vergeUsername,
+		password:      *vergePassword,
+		passwordFile:  *vergePasswordFile,
+		apiKey:        *vergeAPIKey,
+		apiKeyFile:    *vergeAPIKeyFile,
+		insecure:      *insecure,
+		scrapeTimeout: *scrapeTimeout,
+		concurrency:   *concurrency,
+		readyMaxAge:   *readyMaxAge,
+	}
+	s.collectors = enabledCollectors(cfg)
+	s.pollIntervals = pollIntervals(cfg, s.collectors)
+	if cfg == nil {
+		return s
+	}
+
+	if !explicitFlags["verge.url"] && cfg.Verge.URL != "" {
+		s.url = cfg.Verge.URL
+	}
+	if !explicitCredentials() {
+		s.username, s.password, s.apiKey = cfg.Verge.Username, cfg.Verge.Password, cfg.Verge.APIKey
+		s.passwordFile, s.apiKeyFile = cfg.Verge.PasswordFile, cfg.Verge.APIKeyFile
+	}
+	if !explicitFlags["insecure"] && cfg.Verge.Insecure {
+		s.insecure = true
+	}
+	if !explicitFlags["scrape.timeout"] && cfg.Scrape.Timeout > 0 {
+		s.scrapeTimeout = cfg.Scrape.Timeout
+	}
+	if !explicitFlags["scrape.concurrency"] && cfg.Scrape.Concurrency > 0 {
+		s.concurrency = cfg.Scrape.Concurrency
+	}
+
+	if len(cfg.Labels) > 0 {
+		s.labels = prometheus.Labels(cfg.Labels)
+	}
+	s.filters = cfg.nameFilters
+	s.infoLabels = cfg.InfoLabels
+	return s
+}
+
+// explicitCredentials reports whether any credential was given on the command
+// line or through a VERGE_* variable.
+func explicitCredentials() bool {
+	for _, name := range []string{"verge.username", "verge.password", "verge.password-file", "verge.apikey", "verge.apikey-file"} {
+		if explicitFlags[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// enabledCollectors returns the collectors to run, in registration order. A
+// collector is on unless the config file or a -collector.<name>=false or
+// -no-collector.<name> flag turns it off; flags override the file. Collectors
+// in defaultDisabledCollectors are off unless turned on the same way.
+func enabledCollectors(cfg *config) []string {
+	var names []string
+	for _, name := range collectorNames {
+		enabled := !defaultDisabledCollectors[name]
+		if cfg != nil {
+			if v, ok := cfg.Collectors[name]; ok {
+				enabled = v
+			}
+		}
+		if explicitFlags["collector."+name] {
+			enabled = *collectorFlags[name]
+		}
+		if explicitFlags["no-collector."+name] && *noCollectorFlags[name] {
+			enabled = false
+		}
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// pollIntervals returns the background polling interval of each collector in
+// names that polls; collectors without an entry are scraped live. The
+// -scrape.poll-interval flag overrides the file's poll_interval, and the
+// file's per-collector poll_intervals override both.
+func pollIntervals(cfg *config, names []string) map[string]time.Duration {
+	interval := *pollInterval
+	if cfg != nil && !explicitFlags["scrape.poll-interval"] && cfg.Scrape.PollInterval > 0 {
+		interval = cfg.Scrape.PollInterval
+	}
+
+	intervals := make(map[string]time.Duration)
+	for _, name := range names {
+		d := interval
+		if cfg != nil {
+			if v, ok := cfg.Scrape.PollIntervals[name]; ok {
+				d = v
+			}
+		}
+		if d > 0 {
+			intervals[name] = d
+		}
+	}
+	return intervals
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"vergeos-exporter/collectors"
+)
+
+// metricsHandler serves the local cloud's metrics. Without parameters it runs
+// every enabled collector; with node_exporter-style collect[] parameters it
+// runs only the named ones, so separate Prometheus jobs can scrape cheap and
+// expensive collectors at different intervals from the same exporter.
+type metricsHandler struct {
+	collectors map[string]prometheus.Collector
+	labels     prometheus.Labels
+	cache      *collectors.APICache
+	extra      []prometheus.Collector
+	all        http.Handler
+}
+
+// newMetricsHandler registers collectors (in order) into the unfiltered
+// registry, with labels attached to every metric. Each scrape is a scope of
+// cache (which may be nil). The extra collectors (exporter self-metrics) are
+// included in every scrape, filtered or not.
+func newMetricsHandler(collectors map[string]prometheus.Collector, order []string, labels prometheus.Labels, cache *collectors.APICache, extra ...prometheus.Collector) (*metricsHandler, error) {
+	registry, err := newRegistry(collectors, order, labels, extra...)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsHandler{
+		collectors: collectors,
+		labels:     labels,
+		cache:      cache,
+		extra:      extra,
+		all:        promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *metricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer h.cache.Begin()()
+
+	names := r.URL.Query()["collect[]"]
+	if len(names) == 0 {
+		h.all.ServeHTTP(w, r)
+		return
+	}
+
+	seen := make(map[string]bool, len(names))
+	var selected []string
+	for _, name := range names {
+		if _, ok := h.collectors[name]; !ok {
+			http.Error(w, fmt.Sprintf("unknown or disabled collector %q", name), http.StatusBadRequest)
+			return
+		}
+		if !seen[name] {
+			seen[name] = true
+			selected = append(selected, name)
+		}
+	}
+	registry, err := newRegistry(h.collectors, selected, h.labels, h.extra...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, r)
+}
+
+// newRegistry returns a dedicated registry holding the named collectors and
+// extra, so repeated builds don't collide on the global default.
+func newRegistry(collectors map[string]prometheus.Collector, names []string, labels prometheus.Labels, extra ...prometheus.Collector) (*prometheus.Registry, error) {
+	registry := prometheus.NewRegistry()
+	registerer := prometheus.WrapRegistererWith(labels, registry)
+	for _, name := range names {
+		if err := registerer.Register(collectors[name]); err != nil {
+			return nil, fmt.Errorf("failed to register %s collector: %w", name, err)
+		}
+	}
+	for _, c := range extra {
+		if err := registerer.Register(c); err != nil {
+			return nil, fmt.Errorf("failed to register exporter metrics: %w", err)
+		}
+	}
+	return registry, nil
+}
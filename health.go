@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	vergeos "github.com/verge-io/govergeos"
+)
+
+// apiReadiness tracks whether the local cloud's VergeOS API has answered
+// recently, for /-/ready. Besides its own checks, it is told the outcome of
+// every request the client's collectors make (see instrumentedTransport), so
+// a failing API turns the exporter unready on the next scrape rather than
+// after maxAge.
+type apiReadiness struct {
+	client *vergeos.Client
+	maxAge time.Duration
+
+	checkMutex sync.Mutex // serialises calls to the API from check
+
+	mutex       sync.Mutex
+	lastSuccess time.Time
+	lastFailure time.Time
+}
+
+// newAPIReadiness creates an apiReadiness for the local cloud. A successful
+// request within maxAge, and no failed one since, counts as ready without
+// asking the API again. The client is set once it has been built, since its
+// transport reports to the apiReadiness.
+func newAPIReadiness(maxAge time.Duration) *apiReadiness {
+	return &apiReadiness{maxAge: maxAge}
+}
+
+// succeeded records a request the API answered.
+func (r *apiReadiness) succeeded() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lastSuccess = time.Now()
+}
+
+// failed records a request the API did not answer, or answered with a server
+// error or by rejecting the credentials.
+func (r *apiReadiness) failed() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.lastFailure = time.Now()
+}
+
+// recent reports whether the latest request succeeded within maxAge.
+func (r *apiReadiness) recent() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return !r.lastSuccess.Before(r.lastFailure) && time.Since(r.lastSuccess) < r.maxAge
+}
+
+// check returns nil if the latest request succeeded within maxAge, and
+// otherwise calls Settings.GetCloudName to find out. Concurrent checks share
+// one call.
+func (r *apiReadiness) check(ctx context.Context) error {
+	r.checkMutex.Lock()
+	defer r.checkMutex.Unlock()
+
+	if r.recent() {
+		return nil
+	}
+	if _, err := r.client.Settings.GetCloudName(ctx); err != nil {
+		r.failed()
+		return fmt.Errorf("VergeOS API unreachable: %w", err)
+	}
+	r.succeeded()
+	return nil
+}
+
+// serveHealthy handles /-/healthy: the process is up and serving HTTP.
+func serveHealthy(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintln(w, "OK")
+}
+
+// serveReady handles /-/ready: 200 once the exporter has started and, when it
+// scrapes a local cloud, that cloud's API has answered within the readiness
+// window; 503 otherwise. A probe-only exporter is ready once started.
+func (rl *reloader) serveReady(w http.ResponseWriter, r *http.Request) {
+	e := rl.current.Load()
+	if e.startErr != nil {
+		http.Error(w, "not started: "+e.startErr.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if e.ready != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+		defer cancel()
+		if err := e.ready.check(ctx); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	fmt.Fprintln(w, "OK")
+}
+
+// retryStart rebuilds the exporter every interval until it succeeds, a reload
+// from elsewhere succeeds first, or done is closed. It lets the exporter start
+// listening before the VergeOS API is reachable.
+func (rl *reloader) retryStart(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+		if rl.current.Load().startErr == nil {
+			return
+		}
+		if err := rl.reload(); err != nil {
+			log.Printf("Startup failed, retrying in %s: %v", interval, err)
+			continue
+		}
+		return
+	}
+}
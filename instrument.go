@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/tls"
+	"net/http"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	vergeos "github.com/verge-io/govergeos"
+)
+
+// buildInfo reports the exporter build as labels on a constant 1.
+var buildInfo = newBuildInfo()
+
+func newBuildInfo() prometheus.Collector {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "vergeos_exporter_build_info",
+		Help: "Exporter build information (value is always 1)",
+		ConstLabels: prometheus.Labels{
+			"version":   version,
+			"commit":    commit,
+			"date":      date,
+			"goversion": runtime.Version(),
+		},
+	})
+	g.Set(1)
+	return g
+}
+
+// localAuthFailures counts rejected credentials for the local cloud. It is
+// created once, rather than with each generation's apiMetrics, so that the
+// count survives reloads, including those a credential rotation triggers.
+var localAuthFailures = newAuthFailures()
+
+// newAuthFailures creates a vergeos_exporter_auth_failures_total counter.
+func newAuthFailures() prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "vergeos_exporter_auth_failures_total",
+		Help: "VergeOS API requests rejected with 401 or 403, as for a revoked or rotated API key",
+	})
+}
+
+// apiMetrics counts and times the requests one SDK client makes to the
+// VergeOS API. It is a prometheus.Collector so it can be registered alongside
+// that client's collectors.
+type apiMetrics struct {
+	requests     *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	authFailures prometheus.Counter
+}
+
+// newAPIMetrics creates an apiMetrics that counts rejected credentials in
+// authFailures.
+func newAPIMetrics(authFailures prometheus.Counter) *apiMetrics {
+	return &apiMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "vergeos_exporter_api_requests_total",
+			Help: "VergeOS API requests by endpoint and HTTP status code (code=\"error\" for transport failures)",
+		}, []string{"endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "vergeos_exporter_api_request_duration_seconds",
+			Help:    "VergeOS API request latency by endpoint",
+			Buckets: []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"endpoint"}),
+		authFailures: authFailures,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *apiMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.authFailures.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *apiMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.authFailures.Collect(ch)
+}
+
+// instrumentedTransport records every round trip in apiMetrics, and in ready
+// if set. Any request the API rejects with 401 or 403 counts as an auth
+// failure, whichever collector made it.
+type instrumentedTransport struct {
+	next    http.RoundTripper
+	metrics *apiMetrics
+	ready   *apiReadiness
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := apiEndpoint(req.URL.Path)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.metrics.duration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+
+	code := "error"
+	authFailed := false
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+		authFailed = resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden
+		if authFailed {
+			t.metrics.authFailures.Inc()
+		}
+	}
+	t.metrics.requests.WithLabelValues(endpoint, code).Inc()
+
+	// A 404 or other client error still means the API is answering; tables
+	// missing on older VergeOS versions mustn't make the exporter unready.
+	if t.ready != nil {
+		if err != nil || authFailed || resp.StatusCode >= http.StatusInternalServerError {
+			t.ready.failed()
+		} else {
+			t.ready.succeeded()
+		}
+	}
+	return resp, err
+}
+
+// apiEndpoint reduces a request path to its table name ("/api/v4/vms/12"
+// becomes "vms") so the endpoint label stays bounded.
+func apiEndpoint(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, "api/v4/")
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// newClient creates an SDK client whose HTTP requests are recorded in metrics
+// and, if it is not nil, in ready.
+func newClient(baseURL string, auth vergeos.ClientOption, insecureTLS bool, timeout time.Duration, metrics *apiMetrics, ready *apiReadiness) (*vergeos.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: insecureTLS}
+
+	return vergeos.NewClient(
+		vergeos.WithBaseURL(baseURL),
+		auth,
+		vergeos.WithInsecureTLS(insecureTLS),
+		vergeos.WithTimeout(timeout),
+		vergeos.WithHTTPClient(&http.Client{
+			Transport: &instrumentedTransport{next: transport, metrics: metrics, ready: ready},
+			Timeout:   timeout,
+		}),
+	)
+}
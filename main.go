@@ -9,11 +9,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	vergeos "github.com/verge-io/govergeos"
 
 	"vergeos-exporter/collectors"
@@ -32,12 +32,122 @@ var (
 	vergeUsername = flag.String("verge.username", "", "Username for VergeOS API authentication")
 	vergePassword = flag.String("verge.password", "", "Password for VergeOS API authentication")
 	vergeAPIKey   = flag.String("verge.apikey", "", "API key for VergeOS API authentication (alternative to username/password)")
+
+	vergePasswordFile = flag.String("verge.password-file", "", "File containing the VergeOS API password; re-read when it changes.")
+	vergeAPIKeyFile   = flag.String("verge.apikey-file", "", "File containing the VergeOS API key; re-read when it changes.")
+
 	scrapeTimeout = flag.Duration("scrape.timeout", 30*time.Second, "Timeout for scraping VergeOS API")
+	concurrency   = flag.Int("scrape.concurrency", collectors.DefaultConcurrency, "Maximum concurrent per-object VergeOS API requests (per tenant, cluster or network) across all collectors.")
+	pollInterval  = flag.Duration("scrape.poll-interval", 0, "Poll the VergeOS API in the background at this interval and serve cached metrics (0 scrapes on every request).")
 	insecure      = flag.Bool("insecure", false, "Skip TLS certificate verification (use for self-signed certificates)")
 	logFile       = flag.String("log.file", "", "Write logs to this file instead of stderr (useful when running as a service).")
 	serviceAction = flag.String("service", "", "Windows service control action: install, uninstall, start, stop, run (Windows only).")
+	configFile    = flag.String("config.file", "", "Path to a YAML file of connection, credential, collector, label, filter, info_labels and scrape settings, and of the targets and modules served by /probe; re-read on SIGHUP, or POST /-/reload with -web.enable-lifecycle.")
+	readyMaxAge   = flag.Duration("web.ready-max-age", time.Minute, "/-/ready reports ready if the VergeOS API answered within this long, and otherwise asks it again.")
+	retryInterval = flag.Duration("startup.retry-interval", 0, "If the VergeOS API is unreachable at startup, serve and keep retrying at this interval instead of exiting (0 exits).")
+	webConfigFile = flag.String("web.config.file", "", "Path to a Prometheus web configuration file enabling TLS and/or basic authentication on the listener.")
+	webLifecycle  = flag.Bool("web.enable-lifecycle", false, "Enable POST /-/reload to re-read the config file. It is unauthenticated unless -web.config.file sets basic auth.")
 )
 
+// collectorFactory builds one collector against a VergeOS client.
+type collectorFactory func(client *vergeos.Client, scrapeTimeout time.Duration, opts ...collectors.Option) prometheus.Collector
+
+// collectorFactories maps each collector name to its constructor. The names are
+// what /probe modules in the config file refer to.
+var collectorFactories = map[string]collectorFactory{
+	"node": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewNodeCollector(c, t, opts...)
+	},
+	"storage": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewStorageCollector(c, t, opts...)
+	},
+	"network": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewNetworkCollector(c, t, opts...)
+	},
+	"cluster": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewClusterCollector(c, t, opts...)
+	},
+	"system": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewSystemCollector(c, t, opts...)
+	},
+	"tenant": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewTenantCollector(c, t, opts...)
+	},
+	"vm": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewVMCollector(c, t, opts...)
+	},
+	"vnet": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewVNetCollector(c, t, opts...)
+	},
+	"snapshot": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewSnapshotCollector(c, t, opts...)
+	},
+	"sitesync": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewSiteSyncCollector(c, t, opts...)
+	},
+	"alarm": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewAlarmCollector(c, t, opts...)
+	},
+	"log": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewLogCollector(c, t, opts...)
+	},
+	"task": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewTaskCollector(c, t, opts...)
+	},
+	"gpu": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewGPUCollector(c, t, opts...)
+	},
+	"nas": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewNASCollector(c, t, opts...)
+	},
+	"vpn": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewVPNCollector(c, t, opts...)
+	},
+	"routing": func(c *vergeos.Client, t time.Duration, opts ...collectors.Option) prometheus.Collector {
+		return collectors.NewRoutingCollector(c, t, opts...)
+	},
+}
+
+// collectorNames lists every collector in registration order.
+var collectorNames = []string{"node", "storage", "network", "cluster", "system", "tenant", "vm", "vnet", "snapshot", "sitesync", "alarm", "log", "task", "gpu", "nas", "vpn", "routing"}
+
+// defaultDisabledCollectors are off unless enabled by a flag or the config
+// file. The log collector keeps counts between scrapes, so it is opt-in.
+var defaultDisabledCollectors = map[string]bool{"log": true}
+
+// defaultCollectors returns the collectors that are on by default, in
+// registration order.
+func defaultCollectors() []string {
+	var names []string
+	for _, name := range collectorNames {
+		if !defaultDisabledCollectors[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// collectorFlags and noCollectorFlags hold the -collector.<name> and
+// -no-collector.<name> switches for each collector.
+var collectorFlags, noCollectorFlags = registerCollectorFlags()
+
+// registerCollectorFlags defines the enable/disable flag pair for every
+// collector. Collectors are enabled by default unless listed in
+// defaultDisabledCollectors.
+func registerCollectorFlags() (map[string]*bool, map[string]*bool) {
+	enable := make(map[string]*bool, len(collectorNames))
+	disable := make(map[string]*bool, len(collectorNames))
+	for _, name := range collectorNames {
+		enable[name] = flag.Bool("collector."+name, !defaultDisabledCollectors[name], fmt.Sprintf("Enable the %s collector.", name))
+		disable[name] = flag.Bool("no-collector."+name, false, fmt.Sprintf("Disable the %s collector.", name))
+	}
+	return enable, disable
+}
+
+// explicitFlags records the flags set on the command line or through their
+// VERGE_* environment variables. These take precedence over the config file.
+var explicitFlags = map[string]bool{}
+
 func main() {
 	flag.Parse()
 
@@ -53,21 +163,22 @@ func main() {
 
 	log.Printf("vergeos-exporter version=%s commit=%s date=%s", version, commit, date)
 
+	flag.Visit(func(f *flag.Flag) {
+		explicitFlags[f.Name] = true
+	})
+
 	// Environment variable fallback for credentials (avoids exposing secrets in /proc/cmdline)
 	if *vergeURL == "http://localhost" {
 		if v := os.Getenv("VERGE_URL"); v != "" {
 			*vergeURL = v
+			explicitFlags["verge.url"] = true
 		}
 	}
-	if *vergeUsername == "" {
-		*vergeUsername = os.Getenv("VERGE_USERNAME")
-	}
-	if *vergePassword == "" {
-		*vergePassword = os.Getenv("VERGE_PASSWORD")
-	}
-	if *vergeAPIKey == "" {
-		*vergeAPIKey = os.Getenv("VERGE_API_KEY")
-	}
+	envFallback(vergeUsername, "verge.username", "VERGE_USERNAME")
+	envFallback(vergePassword, "verge.password", "VERGE_PASSWORD")
+	envFallback(vergeAPIKey, "verge.apikey", "VERGE_API_KEY")
+	envFallback(vergePasswordFile, "verge.password-file", "VERGE_PASSWORD_FILE")
+	envFallback(vergeAPIKeyFile, "verge.apikey-file", "VERGE_API_KEY_FILE")
 
 	// Windows service control/hosting. On non-Windows platforms this is a no-op
 	// unless -service was passed, in which case it reports a clear error.
@@ -84,86 +195,106 @@ func main() {
 	}
 }
 
+// envFallback fills an unset flag from its environment variable and marks it
+// as explicitly set.
+func envFallback(value *string, flagName, envName string) {
+	if *value != "" {
+		return
+	}
+	if v := os.Getenv(envName); v != "" {
+		*value = v
+		explicitFlags[flagName] = true
+	}
+}
+
 // runExporter builds the SDK client, registers collectors, and serves metrics
 // until stop is closed, an OS signal arrives, or the HTTP server fails. It is
 // platform-neutral: main() calls it directly for foreground runs, and the
 // Windows service handler calls it with a stop channel driven by the SCM.
+//
+// SIGHUP, or a POST to /-/reload when -web.enable-lifecycle is set, re-reads
+// the config file and rebuilds the clients and collectors; the listener stays up, and a failed reload keeps
+// serving the previous state. A successful reload stops the previous
+// generation's background pollers. Changed credential files reload the same
+// way.
 func runExporter(stop <-chan struct{}) error {
-	auth, err := authOption(*vergeAPIKey, *vergeUsername, *vergePassword)
-	if err != nil {
-		return err
-	}
-
-	if *insecure {
-		log.Printf("WARNING: TLS certificate verification is disabled (--insecure flag)")
-	}
-
-	// Create SDK client for API operations
-	client, err := vergeos.NewClient(
-		vergeos.WithBaseURL(*vergeURL),
-		auth,
-		vergeos.WithInsecureTLS(*insecure),
-		vergeos.WithTimeout(*scrapeTimeout),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create VergeOS client: %w", err)
+	var web *webConfig
+	if *webConfigFile != "" {
+		var err error
+		if web, err = loadWebConfig(*webConfigFile); err != nil {
+			return err
+		}
 	}
 
-	// Validate credentials at startup (Bug #34: fail fast with clear error message)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// Reload on SIGHUP or a credential file change until the server exits.
+	done := make(chan struct{})
+	defer close(done)
 
-	cloudName, err := client.Settings.GetCloudName(ctx)
-	if err != nil {
-		if vergeos.IsAuthError(err) {
-			return fmt.Errorf("authentication failed: check API key or username/password for %s", *vergeURL)
+	rl := &reloader{path: *configFile}
+	if err := rl.reload(); err != nil {
+		if *retryInterval <= 0 {
+			return err
 		}
-		return fmt.Errorf("failed to connect to VergeOS API at %s: %w", *vergeURL, err)
+		log.Printf("Startup failed, retrying in %s: %v", *retryInterval, err)
+		rl.current.Store(&exporter{startErr: err})
+		go rl.retryStart(*retryInterval, done)
 	}
-	log.Printf("Successfully connected to VergeOS system: %s", cloudName)
-
-	// Initialize collectors with SDK client and scrape timeout
-	storageCollector := collectors.NewStorageCollector(client, *scrapeTimeout)
-	nodeCollector := collectors.NewNodeCollector(client, *scrapeTimeout)
-	clusterCollector := collectors.NewClusterCollector(client, *scrapeTimeout)
-	networkCollector := collectors.NewNetworkCollector(client, *scrapeTimeout)
-	systemCollector := collectors.NewSystemCollector(client, *scrapeTimeout)
-	tenantCollector := collectors.NewTenantCollector(client, *scrapeTimeout)
-	vmCollector := collectors.NewVMCollector(client, *scrapeTimeout)
-	vnetCollector := collectors.NewVNetCollector(client, *scrapeTimeout)
-
-	// Use a dedicated registry so repeated runs don't collide on the global default.
-	registry := prometheus.NewRegistry()
-	registry.MustRegister(nodeCollector)
-	registry.MustRegister(storageCollector)
-	registry.MustRegister(networkCollector)
-	registry.MustRegister(clusterCollector)
-	registry.MustRegister(systemCollector)
-	registry.MustRegister(tenantCollector)
-	registry.MustRegister(vmCollector)
-	registry.MustRegister(vnetCollector)
 
 	mux := http.NewServeMux()
-	mux.Handle(*metricsPath, promhttp.HandlerFor(
-		registry,
-		promhttp.HandlerOpts{EnableOpenMetrics: true},
-	))
+	mux.Handle(*metricsPath, rl.handler(func(e *exporter) http.Handler {
+		return e.metrics
+	}))
+	mux.Handle("/probe", rl.handler(func(e *exporter) http.Handler {
+		return e.probe
+	}))
+	// Anyone who can reach the listener can force a rebuild, so it is opt-in
+	if *webLifecycle {
+		mux.HandleFunc("/-/reload", rl.serveReload)
+	}
+	mux.HandleFunc("/-/healthy", serveHealthy)
+	mux.HandleFunc("/-/ready", rl.serveReady)
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, `<html>
 			<head><title>VergeOS Exporter</title></head>
 			<body>
 			<h1>VergeOS Exporter</h1>
-			<p><a href="%s">Metrics</a></p>
+			%s
 			</body>
-			</html>`, html.EscapeString(*metricsPath))
+			</html>`, rl.current.Load().links)
 	})
 
+	go rl.watchSecrets(done)
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	go func() {
+		for {
+			select {
+			case <-hup:
+				if err := rl.reload(); err != nil {
+					log.Printf("Reload failed, keeping previous configuration: %v", err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
 	srv := &http.Server{
-		Addr:         *listenAddress,
-		Handler:      mux,
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: *scrapeTimeout + 5*time.Second,
-		IdleTimeout:  60 * time.Second,
+		Addr:        *listenAddress,
+		Handler:     mux,
+		ReadTimeout: 5 * time.Second,
+		IdleTimeout: 60 * time.Second,
+	}
+	if web != nil {
+		srv.Handler = web.requireBasicAuth(mux)
+		if web.TLSServerConfig != nil {
+			tlsConfig, err := web.TLSServerConfig.tlsConfig()
+			if err != nil {
+				return err
+			}
+			srv.TLSConfig = tlsConfig
+		}
 	}
 
 	// Graceful shutdown on SIGINT/SIGTERM or when the caller closes stop
@@ -183,13 +314,163 @@ func runExporter(stop <-chan struct{}) error {
 		}
 	}()
 
-	log.Printf("Starting VergeOS exporter on %s", *listenAddress)
-	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+	// Stop background pollers when the server exits, so a restarted Windows
+	// service doesn't leave the previous run polling.
+	defer rl.close()
+
+	var err error
+	if srv.TLSConfig != nil {
+		log.Printf("Starting VergeOS exporter on %s (TLS)", *listenAddress)
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		log.Printf("Starting VergeOS exporter on %s", *listenAddress)
+		err = srv.ListenAndServe()
+	}
+	if err != http.ErrServerClosed {
 		return fmt.Errorf("server error: %w", err)
 	}
 	return nil
 }
 
+// newExporter loads the config file at path (if any) and builds the handlers
+// it describes.
+func newExporter(path string) (*exporter, error) {
+	var cfg *config
+	if path != "" {
+		var err error
+		if cfg, err = loadConfig(path); err != nil {
+			return nil, err
+		}
+		log.Printf("Loaded %d probe target(s) from %s", len(cfg.Targets), path)
+	}
+	s := resolveSettings(cfg)
+	if err := s.readSecretFiles(); err != nil {
+		return nil, err
+	}
+
+	e := &exporter{writeTimeout: s.scrapeTimeout, secrets: make(map[string]string)}
+
+	// With a config file and no local credentials the exporter only serves
+	// /probe; otherwise it scrapes the local cloud on the metrics path.
+	if cfg == nil || s.hasCredentials() {
+		local, err := newLocalCloud(s)
+		if err != nil {
+			return nil, err
+		}
+		e.metrics = local.metrics
+		e.pollers = local.pollers
+		e.ready = local.ready
+		if s.passwordFile != "" {
+			e.secrets[s.passwordFile] = s.password
+		}
+		if s.apiKeyFile != "" {
+			e.secrets[s.apiKeyFile] = s.apiKey
+		}
+		e.links += fmt.Sprintf(`<p><a href="%s">Metrics</a></p>`, html.EscapeString(*metricsPath))
+	}
+	if cfg != nil {
+		e.probe = newProber(cfg, s.scrapeTimeout, s.concurrency)
+		e.links += `<p>Probe: <code>/probe?target=&lt;name&gt;&amp;module=&lt;name&gt;</code></p>`
+
+		for _, t := range cfg.Targets {
+			if t.PasswordFile != "" {
+				e.secrets[t.PasswordFile] = t.Password
+			}
+			if t.APIKeyFile != "" {
+				e.secrets[t.APIKeyFile] = t.APIKey
+			}
+		}
+
+		// Probe modules may allow longer than the scrape timeout.
+		for _, m := range cfg.Modules {
+			if m.Timeout > e.writeTimeout {
+				e.writeTimeout = m.Timeout
+			}
+		}
+	}
+	return e, nil
+}
+
+// localCloud is what the exporter serves for the cloud on the metrics path.
+type localCloud struct {
+	metrics *metricsHandler
+	pollers []*poller // started for collectors in background polling mode
+	ready   *apiReadiness
+}
+
+// newLocalCloud builds the SDK client for the local cloud, validates the
+// credentials, and returns the metrics handler for the enabled collectors.
+func newLocalCloud(s settings) (*localCloud, error) {
+	auth, err := authOption(s.apiKey, s.username, s.password)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.insecure {
+		log.Printf("WARNING: TLS certificate verification is disabled (--insecure flag)")
+	}
+
+	// Create SDK client for API operations
+	api := newAPIMetrics(localAuthFailures)
+	ready := newAPIReadiness(s.readyMaxAge)
+	client, err := newClient(s.url, auth, s.insecure, s.scrapeTimeout, api, ready)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VergeOS client: %w", err)
+	}
+	ready.client = client
+
+	// Validate credentials at startup (Bug #34: fail fast with clear error message)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cloudName, err := client.Settings.GetCloudName(ctx)
+	if err != nil {
+		if vergeos.IsAuthError(err) {
+			return nil, fmt.Errorf("authentication failed: check API key or username/password for %s", s.url)
+		}
+		return nil, fmt.Errorf("failed to connect to VergeOS API at %s: %w", s.url, err)
+	}
+	log.Printf("Successfully connected to VergeOS system: %s", cloudName)
+
+	// One cache and limiter per client, so collectors scraped together fetch
+	// shared tables once and stay within one API concurrency bound.
+	cache := collectors.NewAPICache(s.scrapeTimeout)
+	limiter := collectors.NewLimiter(s.concurrency)
+
+	enabled := make(map[string]prometheus.Collector, len(s.collectors))
+	var pollers []*poller
+	for _, name := range s.collectors {
+		opts := []collectors.Option{
+			collectors.WithAPICache(cache),
+			collectors.WithLimiter(limiter),
+		}
+		if f, ok := s.filters[name]; ok {
+			opts = append(opts, collectors.WithNameFilter(f))
+		}
+		if labels, ok := s.infoLabels[name]; ok {
+			opts = append(opts, collectors.WithInfoLabels(labels))
+		}
+		c := collectorFactories[name](client, s.scrapeTimeout, opts...)
+		if interval, ok := s.pollIntervals[name]; ok {
+			p := newPoller(name, c, interval, cache)
+			pollers = append(pollers, p)
+			c = p
+			log.Printf("Polling %s collector every %s", name, interval)
+		}
+		enabled[name] = c
+	}
+	log.Printf("Enabled collectors: %s", strings.Join(s.collectors, ", "))
+
+	h, err := newMetricsHandler(enabled, s.collectors, s.labels, cache, api, buildInfo)
+	if err != nil {
+		for _, p := range pollers {
+			p.close()
+		}
+		return nil, err
+	}
+	return &localCloud{metrics: h, pollers: pollers, ready: ready}, nil
+}
+
 func authOption(apiKey, username, password string) (vergeos.ClientOption, error) {
 	if apiKey != "" {
 		return vergeos.WithAPIKey(apiKey), nil
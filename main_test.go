@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	vergeos "github.com/verge-io/govergeos"
+	"golang.org/x/crypto/bcrypt"
+
+	"vergeos-exporter/collectors"
 )
 
 func TestAuthOptionUsesAPIKey(t *testing.T) {
@@ -31,3 +43,683 @@ func TestAuthOptionUsesAPIKey(t *testing.T) {
 		t.Fatal("expected incomplete username/password to fail")
 	}
 }
+
+func writeConfig(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := loadConfig(writeConfig(t, `
+targets:
+  east:
+    url: https://east.example.com
+    api_key: secret
+modules:
+  fast:
+    collectors: [cluster, storage]
+    timeout: 10s
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Targets["east"].URL; got != "https://east.example.com" {
+		t.Errorf("target url = %q", got)
+	}
+	if got := cfg.Modules["fast"].Timeout; got != 10*time.Second {
+		t.Errorf("module timeout = %v, want 10s", got)
+	}
+	if _, ok := cfg.Modules[defaultModule]; !ok {
+		t.Error("expected implicit default module")
+	}
+
+	invalid := map[string]string{
+		"missing url":         "targets:\n  east:\n    api_key: secret\n",
+		"missing auth":        "targets:\n  east:\n    url: https://east.example.com\n",
+		"unknown collector":   "modules:\n  bad:\n    collectors: [nope]\n",
+		"duplicate collector": "modules:\n  bad:\n    collectors: [vm, vm]\n",
+		"unknown field":       "targets:\n  east:\n    url: https://east.example.com\n    apikey: secret\n",
+	}
+	for name, contents := range invalid {
+		if _, err := loadConfig(writeConfig(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestProbeHandler(t *testing.T) {
+	cloud := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/version.json"):
+			fmt.Fprint(w, `{"version":"26.0.0"}`)
+		case strings.Contains(r.URL.Path, "/settings"):
+			fmt.Fprint(w, `[{"key":"cloud_name","value":"east-cloud"}]`)
+		case strings.Contains(r.URL.Path, "/clusters"):
+			fmt.Fprint(w, `[]`)
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+	defer cloud.Close()
+
+	cfg, err := loadConfig(writeConfig(t, fmt.Sprintf(`
+targets:
+  east:
+    url: %s
+    api_key: secret
+modules:
+  clusters:
+    collectors: [cluster]
+  slow:
+    collectors: [cluster]
+    timeout: 20s
+`, cloud.URL)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := newProber(cfg, 5*time.Second, 4)
+
+	probe := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/probe?"+query, nil))
+		return rec
+	}
+
+	rec := probe("target=east&module=clusters")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	if want := `vergeos_clusters_total{system_name="east-cloud"} 0`; !strings.Contains(rec.Body.String(), want) {
+		t.Errorf("probe output missing %q:\n%s", want, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "vergeos_vm_") {
+		t.Error("module limited to cluster collector emitted VM metrics")
+	}
+
+	for _, want := range []string{
+		`vergeos_exporter_api_requests_total{code="200",endpoint="clusters"} 1`,
+		`vergeos_exporter_collector_success{collector="cluster"} 1`,
+		`vergeos_exporter_build_info{`,
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("probe output missing %q", want)
+		}
+	}
+
+	// The client and collectors are cached per target, with a client per
+	// module timeout.
+	probe("target=east&module=clusters")
+	if n := len(handler.targets); n != 1 {
+		t.Errorf("cached targets = %d, want 1", n)
+	}
+	if rec := probe("target=east&module=slow"); rec.Code != http.StatusOK {
+		t.Fatalf("slow module: status = %d", rec.Code)
+	}
+	if n := len(handler.targets["east"].clients); n != 2 {
+		t.Errorf("cached clients = %d, want 2", n)
+	}
+
+	for _, query := range []string{"", "target=west", "target=east&module=nope"} {
+		if rec := probe(query); rec.Code != http.StatusBadRequest {
+			t.Errorf("probe %q: status = %d, want 400", query, rec.Code)
+		}
+	}
+}
+
+func TestResolveSettings(t *testing.T) {
+	cfg, err := loadConfig(writeConfig(t, `
+verge:
+  url: https://file.example.com
+  api_key: file-key
+  insecure: true
+scrape:
+  timeout: 45s
+  concurrency: 16
+  poll_interval: 1m
+  poll_intervals:
+    tenant: 5m
+collectors:
+  vm: false
+  vnet: false
+labels:
+  datacenter: east
+filters:
+  tenant:
+    include: ^prod-
+info_labels:
+  vm: [os_family, tags]
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := resolveSettings(cfg)
+	if s.url != "https://file.example.com" || s.apiKey != "file-key" || !s.insecure {
+		t.Errorf("file connection settings not applied: %+v", s)
+	}
+	if s.scrapeTimeout != 45*time.Second {
+		t.Errorf("scrapeTimeout = %v, want 45s", s.scrapeTimeout)
+	}
+	if s.concurrency != 16 {
+		t.Errorf("concurrency = %d, want 16", s.concurrency)
+	}
+	if s.pollIntervals["cluster"] != time.Minute || s.pollIntervals["tenant"] != 5*time.Minute {
+		t.Errorf("pollIntervals = %v", s.pollIntervals)
+	}
+	if _, ok := s.pollIntervals["vm"]; ok {
+		t.Error("disabled vm collector given a poll interval")
+	}
+	if got := strings.Join(s.collectors, ","); got != "node,storage,network,cluster,system,tenant,snapshot,sitesync,alarm,task,gpu,nas,vpn,routing" {
+		t.Errorf("collectors = %s", got)
+	}
+	if s.labels["datacenter"] != "east" {
+		t.Errorf("labels = %v", s.labels)
+	}
+	if f, ok := s.filters["tenant"]; !ok || !f.Match("prod-a") || f.Match("dev-a") {
+		t.Errorf("tenant filter not applied: %+v", s.filters)
+	}
+	if got := strings.Join(s.infoLabels["vm"], ","); got != "os_family,tags" {
+		t.Errorf("vm info labels = %s", got)
+	}
+
+	// Explicit flags win, and credentials are taken as a unit.
+	oldURL, oldUser, oldPass := *vergeURL, *vergeUsername, *vergePassword
+	defer func() {
+		*vergeURL, *vergeUsername, *vergePassword = oldURL, oldUser, oldPass
+		explicitFlags = map[string]bool{}
+	}()
+	*vergeURL, *vergeUsername, *vergePassword = "https://flag.example.com", "admin", "secret"
+	explicitFlags = map[string]bool{"verge.url": true, "verge.username": true, "verge.password": true}
+
+	s = resolveSettings(cfg)
+	if s.url != "https://flag.example.com" {
+		t.Errorf("url = %q, want flag value", s.url)
+	}
+	if s.apiKey != "" || s.username != "admin" || s.password != "secret" {
+		t.Errorf("credentials mixed file and flags: %+v", s)
+	}
+
+	invalid := map[string]string{
+		"unknown collector":    "collectors:\n  nope: true\n",
+		"bad label":            "labels:\n  bad-name: x\n",
+		"unfilterable":         "filters:\n  storage:\n    include: x\n",
+		"bad regexp":           "filters:\n  vm:\n    exclude: \"(\"\n",
+		"no info metric":       "info_labels:\n  node: [os_family]\n",
+		"unknown info label":   "info_labels:\n  vm: [nope]\n",
+		"duplicate info label": "info_labels:\n  vm: [os_family, os_family]\n",
+		"incomplete creds":     "verge:\n  username: admin\n",
+		"negative timeout":     "scrape:\n  timeout: -1s\n",
+		"unknown verge knob":   "verge:\n  api-key: x\n",
+		"negative poll":        "scrape:\n  poll_interval: -1s\n",
+		"negative limit":       "scrape:\n  concurrency: -1\n",
+		"unknown poll":         "scrape:\n  poll_intervals:\n    nope: 1m\n",
+	}
+	for name, contents := range invalid {
+		if _, err := loadConfig(writeConfig(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestReloader(t *testing.T) {
+	cloud := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/version.json"):
+			fmt.Fprint(w, `{"version":"26.0.0"}`)
+		case strings.Contains(r.URL.Path, "/settings"):
+			fmt.Fprint(w, `[{"key":"cloud_name","value":"east-cloud"}]`)
+		case strings.Contains(r.URL.Path, "/clusters"):
+			fmt.Fprint(w, `[]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer cloud.Close()
+
+	path := writeConfig(t, fmt.Sprintf(`
+verge:
+  url: %s
+  api_key: secret
+collectors: {node: false, storage: false, network: false, system: false, tenant: false, vm: false, vnet: false}
+labels:
+  site: one
+`, cloud.URL))
+
+	rl := &reloader{path: path}
+	if err := rl.reload(); err != nil {
+		t.Fatal(err)
+	}
+	metrics := rl.handler(func(e *exporter) http.Handler {
+		return e.metrics
+	})
+	scrape := func() string {
+		rec := httptest.NewRecorder()
+		metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+	if want := `vergeos_clusters_total{site="one",system_name="east-cloud"} 0`; !strings.Contains(scrape(), want) {
+		t.Fatalf("metrics missing %q:\n%s", want, scrape())
+	}
+
+	post := func(method string) int {
+		rec := httptest.NewRecorder()
+		rl.serveReload(rec, httptest.NewRequest(method, "/-/reload", nil))
+		return rec.Code
+	}
+
+	// A changed file is picked up by /-/reload.
+	contents, _ := os.ReadFile(path)
+	if err := os.WriteFile(path, []byte(strings.Replace(string(contents), "site: one", "site: two", 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if code := post(http.MethodPost); code != http.StatusOK {
+		t.Fatalf("reload status = %d", code)
+	}
+	if want := `site="two"`; !strings.Contains(scrape(), want) {
+		t.Errorf("reloaded metrics missing %q", want)
+	}
+
+	// A broken file is rejected and the previous state keeps serving.
+	if err := os.WriteFile(path, []byte("verge: [\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if code := post(http.MethodPost); code != http.StatusInternalServerError {
+		t.Errorf("broken reload status = %d, want 500", code)
+	}
+	if want := `site="two"`; !strings.Contains(scrape(), want) {
+		t.Errorf("previous state lost after failed reload")
+	}
+
+	if code := post(http.MethodGet); code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /-/reload status = %d, want 405", code)
+	}
+}
+
+func TestEnabledCollectors(t *testing.T) {
+	defer func() {
+		*collectorFlags["vm"] = true
+		*noCollectorFlags["storage"] = false
+		explicitFlags = map[string]bool{}
+	}()
+
+	cfg := &config{Collectors: map[string]bool{"vnet": false, "vm": false}}
+	if got := strings.Join(enabledCollectors(cfg), ","); got != "node,storage,network,cluster,system,tenant,snapshot,sitesync,alarm,task,gpu,nas,vpn,routing" {
+		t.Errorf("file only: %s", got)
+	}
+
+	// -collector.vm re-enables what the file disabled; -no-collector.storage
+	// disables a collector the file left alone.
+	*collectorFlags["vm"] = true
+	*noCollectorFlags["storage"] = true
+	explicitFlags = map[string]bool{"collector.vm": true, "no-collector.storage": true}
+	if got := strings.Join(enabledCollectors(cfg), ","); got != "node,network,cluster,system,tenant,vm,snapshot,sitesync,alarm,task,gpu,nas,vpn,routing" {
+		t.Errorf("with flags: %s", got)
+	}
+
+	// The log collector is off unless the file turns it on.
+	cfg.Collectors["log"] = true
+	if got := enabledCollectors(cfg); !slices.Contains(got, "log") {
+		t.Errorf("log not enabled by the file: %v", got)
+	}
+}
+
+func TestMetricsHandlerCollectFilter(t *testing.T) {
+	gauge := func(name string) prometheus.Collector {
+		g := prometheus.NewGauge(prometheus.GaugeOpts{Name: name, Help: name})
+		g.Set(1)
+		return g
+	}
+	h, err := newMetricsHandler(map[string]prometheus.Collector{
+		"cluster": gauge("test_cluster"),
+		"vm":      gauge("test_vm"),
+	}, []string{"cluster", "vm"}, prometheus.Labels{"site": "east"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics?"+query, nil))
+		return rec
+	}
+
+	body := get("").Body.String()
+	if !strings.Contains(body, `test_cluster{site="east"} 1`) || !strings.Contains(body, `test_vm{site="east"} 1`) {
+		t.Errorf("unfiltered scrape missing metrics:\n%s", body)
+	}
+
+	body = get("collect[]=vm&collect[]=vm").Body.String()
+	if !strings.Contains(body, `test_vm{site="east"} 1`) || strings.Contains(body, "test_cluster") {
+		t.Errorf("collect[]=vm returned:\n%s", body)
+	}
+
+	if rec := get("collect[]=storage"); rec.Code != http.StatusBadRequest {
+		t.Errorf("disabled collector status = %d, want 400", rec.Code)
+	}
+}
+
+func TestAPIEndpoint(t *testing.T) {
+	for path, want := range map[string]string{
+		"/api/v4/vms":              "vms",
+		"/api/v4/clusters/3":       "clusters",
+		"/api/v4/cluster_tiers/12": "cluster_tiers",
+		"/version.json":            "version.json",
+		"/":                        "/",
+	} {
+		if got := apiEndpoint(path); got != want {
+			t.Errorf("apiEndpoint(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+// flakyCollector emits one gauge whose value counts successful collections,
+// plus the usual duration and success, and fails whenever fail is set.
+type flakyCollector struct {
+	*collectors.BaseCollector
+	desc  *prometheus.Desc
+	calls float64
+	fail  bool
+}
+
+func (c *flakyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+	c.DescribeScrape(ch)
+}
+
+func (c *flakyCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Run(ch, func(ctx context.Context, ch chan<- prometheus.Metric) error {
+		if c.fail {
+			return fmt.Errorf("api down")
+		}
+		c.calls++
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, c.calls)
+		return nil
+	})
+}
+
+func TestPoller(t *testing.T) {
+	c := &flakyCollector{
+		BaseCollector: collectors.NewBaseCollector("test", nil, time.Second),
+		desc:          prometheus.NewDesc("test_polls", "test", nil, nil),
+	}
+	p := &poller{
+		name:      "test",
+		collector: c,
+		lastSuccess: prometheus.NewDesc(
+			"vergeos_exporter_collector_last_success_timestamp_seconds", "test",
+			nil, prometheus.Labels{"collector": "test"},
+		),
+	}
+
+	scrape := func() string {
+		h, err := newMetricsHandler(map[string]prometheus.Collector{"test": p}, []string{"test"}, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		return rec.Body.String()
+	}
+
+	if body := scrape(); strings.Contains(body, "test_polls") || !strings.Contains(body, `last_success_timestamp_seconds{collector="test"} 0`) {
+		t.Errorf("scrape before first poll:\n%s", body)
+	}
+
+	p.poll()
+	body := scrape()
+	if !strings.Contains(body, "test_polls 1") || strings.Contains(body, `last_success_timestamp_seconds{collector="test"} 0`) ||
+		!strings.Contains(body, `vergeos_exporter_collector_success{collector="test"} 1`) {
+		t.Errorf("scrape after first poll:\n%s", body)
+	}
+
+	// Scrapes serve the snapshot without calling the collector.
+	scrape()
+	if c.calls != 1 {
+		t.Errorf("collector called %v times, want 1", c.calls)
+	}
+
+	// A failed poll keeps the last good snapshot, but reports the failure.
+	c.fail = true
+	p.poll()
+	body = scrape()
+	if !strings.Contains(body, "test_polls 1") {
+		t.Errorf("snapshot lost after failed poll:\n%s", body)
+	}
+	if !strings.Contains(body, `vergeos_exporter_collector_success{collector="test"} 0`) ||
+		strings.Contains(body, `vergeos_exporter_collector_success{collector="test"} 1`) {
+		t.Errorf("failed poll not reported:\n%s", body)
+	}
+}
+
+func TestWebConfig(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadWebConfig(writeConfig(t, fmt.Sprintf("basic_auth_users:\n  prometheus: %s\n", hash)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := cfg.requireBasicAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}))
+	get := func(user, password string) int {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if user != "" {
+			req.SetBasicAuth(user, password)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := get("prometheus", "secret"); code != http.StatusOK {
+		t.Errorf("valid credentials: status = %d", code)
+	}
+	for _, creds := range [][2]string{{"", ""}, {"prometheus", "wrong"}, {"nobody", "secret"}} {
+		if code := get(creds[0], creds[1]); code != http.StatusUnauthorized {
+			t.Errorf("credentials %q: status = %d, want 401", creds, code)
+		}
+	}
+
+	// http_server_config, common in node_exporter files, is ignored.
+	if _, err := loadWebConfig(writeConfig(t, fmt.Sprintf("http_server_config:\n  http2: false\nbasic_auth_users:\n  prometheus: %s\n", hash))); err != nil {
+		t.Errorf("http_server_config: %v", err)
+	}
+
+	invalid := map[string]string{
+		"plaintext password": "basic_auth_users:\n  prometheus: secret\n",
+		"missing key":        "tls_server_config:\n  cert_file: cert.pem\n",
+		"unreadable cert":    "tls_server_config:\n  cert_file: /nonexistent/cert.pem\n  key_file: /nonexistent/key.pem\n",
+		"unknown field":      "tls_server_config:\n  certfile: cert.pem\n",
+		"unsupported tls":    "tls_server_config:\n  cert_file: cert.pem\n  key_file: key.pem\n  cipher_suites: [TLS_AES_128_GCM_SHA256]\n",
+	}
+	for name, contents := range invalid {
+		if _, err := loadWebConfig(writeConfig(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCredentialFiles(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "api_key")
+	if err := os.WriteFile(keyFile, []byte("file-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig(writeConfig(t, fmt.Sprintf(`
+verge:
+  url: https://file.example.com
+  api_key_file: %[1]s
+targets:
+  east:
+    url: https://east.example.com
+    api_key_file: %[1]s
+`, keyFile)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Targets["east"].APIKey; got != "file-key" {
+		t.Errorf("target api key = %q, want file contents without newline", got)
+	}
+
+	s := resolveSettings(cfg)
+	if !s.hasCredentials() {
+		t.Error("credential file not counted as local credentials")
+	}
+	if err := s.readSecretFiles(); err != nil {
+		t.Fatal(err)
+	}
+	if s.apiKey != "file-key" {
+		t.Errorf("local api key = %q", s.apiKey)
+	}
+
+	empty := filepath.Join(dir, "empty")
+	if err := os.WriteFile(empty, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := map[string]string{
+		"key and file": fmt.Sprintf("verge:\n  api_key: x\n  api_key_file: %s\n", keyFile),
+		"missing file": "verge:\n  password_file: /nonexistent/password\n  username: admin\n",
+		"empty file":   fmt.Sprintf("targets:\n  east:\n    url: https://east.example.com\n    api_key_file: %s\n", empty),
+	}
+	for name, contents := range invalid {
+		if _, err := loadConfig(writeConfig(t, contents)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestAuthFailures(t *testing.T) {
+	var revoked atomic.Bool
+	cloud := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/version.json"):
+			fmt.Fprint(w, `{"version":"26.0.0"}`)
+		case revoked.Load():
+			http.Error(w, `{"err":"unauthorized"}`, http.StatusUnauthorized)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer cloud.Close()
+
+	failures := newAuthFailures()
+	client, err := newClient(cloud.URL, vergeos.WithAPIKey("secret"), false, time.Second, newAPIMetrics(failures), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if _, err := client.Clusters.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A key revoked after startup is counted by whichever collector's request
+	// the API rejects, not only when fetching the cloud name.
+	revoked.Store(true)
+	client.Clusters.List(ctx)
+	client.VMs.List(ctx)
+	if got := testutil.ToFloat64(failures); got != 2 {
+		t.Errorf("auth failures = %v, want 2", got)
+	}
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	var down atomic.Bool
+	cloud := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/version.json"):
+			fmt.Fprint(w, `{"version":"26.0.0"}`)
+		case strings.Contains(r.URL.Path, "/settings"):
+			fmt.Fprint(w, `[{"key":"cloud_name","value":"east-cloud"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	}))
+	defer cloud.Close()
+
+	rl := &reloader{}
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		rl.serveReady(rec, httptest.NewRequest(http.MethodGet, "/-/ready", nil))
+		return rec.Code
+	}
+
+	rec := httptest.NewRecorder()
+	serveHealthy(rec, httptest.NewRequest(http.MethodGet, "/-/healthy", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("healthy status = %d", rec.Code)
+	}
+
+	// Not started yet: ready and the metrics path both report 503.
+	rl.current.Store(&exporter{startErr: fmt.Errorf("connection refused")})
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Errorf("ready before start = %d, want 503", code)
+	}
+	rec = httptest.NewRecorder()
+	rl.handler(func(e *exporter) http.Handler { return e.metrics }).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("metrics before start = %d, want 503", rec.Code)
+	}
+
+	// A probe-only exporter is ready once started.
+	rl.current.Store(&exporter{})
+	if code := ready(); code != http.StatusOK {
+		t.Errorf("probe-only ready = %d, want 200", code)
+	}
+
+	// With a local cloud, readiness follows the API once the window passes.
+	newReadiness := func(maxAge time.Duration) (*apiReadiness, *vergeos.Client) {
+		r := newAPIReadiness(maxAge)
+		client, err := newClient(cloud.URL, vergeos.WithAPIKey("secret"), false, time.Second, newAPIMetrics(newAuthFailures()), r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.client = client
+		return r, client
+	}
+	r, _ := newReadiness(0)
+	rl.current.Store(&exporter{ready: r})
+	if code := ready(); code != http.StatusOK {
+		t.Errorf("ready with API up = %d, want 200", code)
+	}
+	down.Store(true)
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Errorf("ready with API down = %d, want 503", code)
+	}
+
+	// A recent success is trusted without asking the API, until one of the
+	// collectors' requests fails.
+	down.Store(false)
+	r, client := newReadiness(time.Minute)
+	rl.current.Store(&exporter{ready: r})
+	ctx := context.Background()
+	if _, err := client.Clusters.List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	down.Store(true)
+	if code := ready(); code != http.StatusOK {
+		t.Errorf("ready within window = %d, want 200", code)
+	}
+	client.Clusters.List(ctx)
+	if code := ready(); code != http.StatusServiceUnavailable {
+		t.Errorf("ready after failed collector request = %d, want 503", code)
+	}
+	down.Store(false)
+	if code := ready(); code != http.StatusOK {
+		t.Errorf("ready after API recovered = %d, want 200", code)
+	}
+}
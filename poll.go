@@ -0,0 +1,137 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"vergeos-exporter/collectors"
+)
+
+// poller runs a collector on its own interval in the background and serves
+// the metrics of its last successful run, so scrapes never wait on the
+// VergeOS API and any number of Prometheus replicas cost one set of API calls.
+// The collector's duration and success metrics always come from its latest
+// run, so a failing poll shows as vergeos_exporter_collector_success 0.
+type poller struct {
+	name      string
+	collector prometheus.Collector
+	interval  time.Duration
+	cache     *collectors.APICache
+
+	lastSuccess *prometheus.Desc
+
+	mutex           sync.RWMutex
+	snapshot        []prometheus.Metric // data series from the last good poll
+	scrape          []prometheus.Metric // duration and success of the latest poll
+	lastSuccessTime time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newPoller wraps collector and starts polling it immediately. Each poll is a
+// scope of cache (which may be nil), so pollers that run together share API
+// responses. Until the first successful poll, scrapes return only the
+// latest duration and success and the last-success gauge (at 0).
+func newPoller(name string, collector prometheus.Collector, interval time.Duration, cache *collectors.APICache) *poller {
+	p := &poller{
+		name:      name,
+		collector: collector,
+		interval:  interval,
+		cache:     cache,
+		lastSuccess: prometheus.NewDesc(
+			"vergeos_exporter_collector_last_success_timestamp_seconds",
+			"Unix time of the collector's last successful background poll (0 if none yet)",
+			nil, prometheus.Labels{"collector": name},
+		),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+// run polls until close is called. Polls never overlap: a poll that outlasts
+// the interval delays the next one.
+func (p *poller) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.poll()
+		select {
+		case <-ticker.C:
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// poll collects once and replaces the snapshot if the collector succeeded.
+// A failed poll keeps the previous snapshot, whose age the last-success gauge
+// shows, but still replaces the duration and success metrics.
+func (p *poller) poll() {
+	defer p.cache.Begin()()
+
+	ch := make(chan prometheus.Metric)
+	var metrics, scrape []prometheus.Metric
+	go func() {
+		p.collector.Collect(ch)
+		close(ch)
+	}()
+	sc, _ := p.collector.(interface{ IsScrapeMetric(prometheus.Metric) bool })
+	for m := range ch {
+		if sc != nil && sc.IsScrapeMetric(m) {
+			scrape = append(scrape, m)
+		} else {
+			metrics = append(metrics, m)
+		}
+	}
+
+	// The collector has already logged the failure.
+	failed := false
+	if c, ok := p.collector.(interface{ LastError() error }); ok && c.LastError() != nil {
+		failed = true
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.scrape = scrape
+	if !failed {
+		p.snapshot = metrics
+		p.lastSuccessTime = time.Now()
+	}
+}
+
+// close stops polling and waits for a poll in progress to finish.
+func (p *poller) close() {
+	close(p.stop)
+	<-p.done
+}
+
+// Describe implements prometheus.Collector.
+func (p *poller) Describe(ch chan<- *prometheus.Desc) {
+	p.collector.Describe(ch)
+	ch <- p.lastSuccess
+}
+
+// Collect implements prometheus.Collector.
+func (p *poller) Collect(ch chan<- prometheus.Metric) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	for _, m := range p.snapshot {
+		ch <- m
+	}
+	for _, m := range p.scrape {
+		ch <- m
+	}
+	var ts float64
+	if !p.lastSuccessTime.IsZero() {
+		ts = float64(p.lastSuccessTime.UnixNano()) / 1e9
+	}
+	ch <- prometheus.MustNewConstMetric(p.lastSuccess, prometheus.GaugeValue, ts)
+}
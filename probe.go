@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	vergeos "github.com/verge-io/govergeos"
+
+	"vergeos-exporter/collectors"
+)
+
+// prober serves blackbox-style /probe?target=<cloud>&module=<name> requests,
+// scraping one of the clouds named in the config file into a throwaway
+// registry. SDK clients and collectors are built on first use and reused, so
+// the cached system name and connection pool survive between probes.
+type prober struct {
+	cfg         *config
+	timeout     time.Duration
+	concurrency int
+
+	mutex   sync.Mutex
+	targets map[string]*probeTarget
+}
+
+// probeTarget is the lazily built state for one cloud. Modules with
+// different timeouts get their own client, since the timeout is fixed when
+// the client is built, but share the cloud's concurrency limit.
+type probeTarget struct {
+	limiter *collectors.Limiter
+	clients map[time.Duration]*probeClient
+}
+
+// probeClient is the SDK client, API cache and collector set used by the
+// modules with one timeout against one cloud.
+type probeClient struct {
+	client     *vergeos.Client
+	api        *apiMetrics
+	cache      *collectors.APICache
+	collectors map[string]prometheus.Collector
+}
+
+// newProber creates a prober for cfg. timeout is used for modules that do not
+// set their own; concurrency bounds each target's per-object API requests.
+func newProber(cfg *config, timeout time.Duration, concurrency int) *prober {
+	return &prober{
+		cfg:         cfg,
+		timeout:     timeout,
+		concurrency: concurrency,
+		targets:     make(map[string]*probeTarget),
+	}
+}
+
+// ServeHTTP implements http.Handler.
+func (p *prober) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	targetName := r.URL.Query().Get("target")
+	if targetName == "" {
+		http.Error(w, "target parameter is missing", http.StatusBadRequest)
+		return
+	}
+	tc, ok := p.cfg.Targets[targetName]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown target %q", targetName), http.StatusBadRequest)
+		return
+	}
+
+	moduleName := r.URL.Query().Get("module")
+	if moduleName == "" {
+		moduleName = defaultModule
+	}
+	module, ok := p.cfg.Modules[moduleName]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown module %q", moduleName), http.StatusBadRequest)
+		return
+	}
+
+	timeout := module.Timeout
+	if timeout == 0 {
+		timeout = p.timeout
+	}
+
+	pc, collectors, err := p.collectors(targetName, tc, module, timeout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer pc.cache.Begin()()
+
+	registry := prometheus.NewRegistry()
+	for _, c := range collectors {
+		registry.MustRegister(c)
+	}
+	registry.MustRegister(pc.api, buildInfo)
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, r)
+}
+
+// collectors returns the named target's client for timeout and the
+// collectors module asks for on it, creating the client and any missing
+// collectors on first use.
+func (p *prober) collectors(name string, tc targetConfig, module moduleConfig, timeout time.Duration) (*probeClient, []prometheus.Collector, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	target, ok := p.targets[name]
+	if !ok {
+		target = &probeTarget{
+			limiter: collectors.NewLimiter(p.concurrency),
+			clients: make(map[time.Duration]*probeClient),
+		}
+		p.targets[name] = target
+	}
+
+	pc, ok := target.clients[timeout]
+	if !ok {
+		auth, err := authOption(tc.APIKey, tc.Username, tc.Password)
+		if err != nil {
+			return nil, nil, fmt.Errorf("target %q: %w", name, err)
+		}
+		api := newAPIMetrics(newAuthFailures())
+		client, err := newClient(tc.URL, auth, tc.Insecure, timeout, api, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create VergeOS client for target %q: %w", name, err)
+		}
+		pc = &probeClient{
+			client:     client,
+			api:        api,
+			cache:      collectors.NewAPICache(timeout),
+			collectors: make(map[string]prometheus.Collector),
+		}
+		target.clients[timeout] = pc
+	}
+
+	names := module.Collectors
+	if len(names) == 0 {
+		names = defaultCollectors()
+	}
+
+	var out []prometheus.Collector
+	for _, n := range names {
+		c, ok := pc.collectors[n]
+		if !ok {
+			c = collectorFactories[n](pc.client, timeout,
+				collectors.WithAPICache(pc.cache),
+				collectors.WithLimiter(target.limiter),
+			)
+			pc.collectors[n] = c
+		}
+		out = append(out, c)
+	}
+	return pc, out, nil
+}
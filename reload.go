@@ -0,0 +1,134 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// exporter is one generation of served state: the handlers built from a
+// single read of the config file. Either handler may be nil. Until the first
+// generation builds, a placeholder holding only startErr serves 503s.
+type exporter struct {
+	metrics      http.Handler
+	probe        http.Handler
+	ready        *apiReadiness
+	startErr     error
+	pollers      []*poller
+	secrets      map[string]string // credential file -> contents in use
+	links        string
+	writeTimeout time.Duration
+}
+
+// close stops the generation's background pollers. Scrapes still in flight
+// keep reading the last snapshots.
+func (e *exporter) close() {
+	for _, p := range e.pollers {
+		p.close()
+	}
+}
+
+// reloader owns the current exporter and replaces it on SIGHUP or, with
+// -web.enable-lifecycle, a POST to /-/reload. Requests already in flight finish against the generation they
+// started with.
+type reloader struct {
+	path string
+
+	mutex   sync.Mutex // serialises reloads
+	current atomic.Pointer[exporter]
+}
+
+// reload rebuilds the exporter from the config file and swaps it in. On error
+// the previous generation keeps serving.
+func (rl *reloader) reload() error {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	e, err := newExporter(rl.path)
+	if err != nil {
+		return err
+	}
+	if old := rl.current.Swap(e); old != nil {
+		old.close()
+		log.Printf("Configuration reloaded")
+	}
+	return nil
+}
+
+// close stops the current generation's background pollers.
+func (rl *reloader) close() {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	if e := rl.current.Load(); e != nil {
+		e.close()
+	}
+}
+
+// handler returns an http.Handler that serves whichever handler pick selects
+// from the current generation, or 404 if that generation has none. The write
+// deadline follows the generation's timeout so a reload can raise it.
+func (rl *reloader) handler(pick func(*exporter) http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e := rl.current.Load()
+		if e.startErr != nil {
+			http.Error(w, "exporter not started: "+e.startErr.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		h := pick(e)
+		if h == nil {
+			http.NotFound(w, r)
+			return
+		}
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(e.writeTimeout + 5*time.Second))
+		h.ServeHTTP(w, r)
+	})
+}
+
+// secretCheckInterval is how often credential files are checked for changes.
+const secretCheckInterval = 30 * time.Second
+
+// watchSecrets reloads whenever a credential file of the current generation
+// no longer holds the secret that generation was built with, so rotated API
+// keys and passwords take effect without a restart. A reload that fails (the
+// new secret is rejected, or the file is mid-update) is retried on the next
+// check. It returns when done is closed.
+func (rl *reloader) watchSecrets(done <-chan struct{}) {
+	ticker := time.NewTicker(secretCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+
+		for path, inUse := range rl.current.Load().secrets {
+			if secret, err := readSecretFile(path); err == nil && secret == inUse {
+				continue
+			}
+			log.Printf("Credential file %s changed or unreadable, reloading", path)
+			if err := rl.reload(); err != nil {
+				log.Printf("Reload failed, keeping previous configuration: %v", err)
+			}
+			break
+		}
+	}
+}
+
+// serveReload handles POST /-/reload.
+func (rl *reloader) serveReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "POST, PUT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := rl.reload(); err != nil {
+		log.Printf("Reload failed, keeping previous configuration: %v", err)
+		http.Error(w, "reload failed: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
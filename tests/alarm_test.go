@@ -0,0 +1,50 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestAlarmCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	alarms := []AlarmMock{
+		{Key: 1, Level: "critical", Type: "Drive Failure", OwnerType: "node", OwnerName: "node1"},
+		{Key: 2, Level: "warning", Type: "Tier Not Redundant", OwnerType: "cluster_tier", OwnerName: "Tier 1"},
+		{Key: 3, Level: "warning", Type: "Tier Not Redundant", OwnerType: "cluster_tier", OwnerName: "Tier 1"},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.Contains(r.URL.Path, "/alarms") {
+			WriteJSONResponse(w, alarms)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewAlarmCollector(client, TestScrapeTimeout)
+
+	// Identical alarms share a series; every level has a count.
+	expected := `
+		# HELP vergeos_alarm_active Number of active alarms of this level and type raised on the owner (normally 1)
+		# TYPE vergeos_alarm_active gauge
+		vergeos_alarm_active{level="critical",owner="node1",owner_type="node",system_name="testcloud",type="Drive Failure"} 1
+		vergeos_alarm_active{level="warning",owner="Tier 1",owner_type="cluster_tier",system_name="testcloud",type="Tier Not Redundant"} 2
+		# HELP vergeos_alarms_total Number of active alarms by level
+		# TYPE vergeos_alarms_total gauge
+		vergeos_alarms_total{level="critical",system_name="testcloud"} 1
+		vergeos_alarms_total{level="error",system_name="testcloud"} 0
+		vergeos_alarms_total{level="message",system_name="testcloud"} 0
+		vergeos_alarms_total{level="warning",system_name="testcloud"} 2
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_alarm_active", "vergeos_alarms_total"); err != nil {
+		t.Errorf("Unexpected metric values: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestAPICache_SharedAcrossCollectors(t *testing.T) {
+	config := DefaultMockConfig()
+
+	nodes := []NodeMock{
+		{ID: 1, Name: "node1", Physical: true, Cluster: 1, Machine: 101, IPMIStatus: "ok", RAM: 65536},
+	}
+	clusters := []ClusterMock{
+		{Key: 1, Name: "cluster1", Enabled: true},
+	}
+	nics := []MachineNICMock{
+		{
+			Key: 1, Machine: 101, Name: "eno1",
+			Stats:  &MachineNICStatsMock{Key: 1, TxPckts: 1000, RxPckts: 2000, TxBytes: 100000, RxBytes: 200000},
+			Status: &MachineNICStatusMock{Key: 1, Status: "up", Speed: 10000},
+		},
+	}
+
+	var clusterCalls, nicCalls atomic.Int32
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/clusters"):
+			clusterCalls.Add(1)
+			WriteJSONResponse(w, clusters)
+			return true
+		case strings.Contains(r.URL.Path, "/nodes") && strings.Contains(r.URL.RawQuery, "physical"):
+			WriteJSONResponse(w, nodes)
+			return true
+		case strings.Contains(r.URL.Path, "/machine_nics"):
+			nicCalls.Add(1)
+			WriteJSONResponse(w, nics)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	cache := collectors.NewAPICache(TestScrapeTimeout)
+	first := collectors.NewNetworkCollector(client, TestScrapeTimeout, collectors.WithAPICache(cache))
+	second := collectors.NewNetworkCollector(client, TestScrapeTimeout, collectors.WithAPICache(cache))
+
+	t.Run("within_scrape", func(t *testing.T) {
+		end := cache.Begin()
+		testutil.CollectAndCount(first, "vergeos_nic_tx_bytes_total")
+		testutil.CollectAndCount(second, "vergeos_nic_tx_bytes_total")
+		end()
+
+		if n := clusterCalls.Load(); n != 1 {
+			t.Errorf("Expected 1 clusters request, got %d", n)
+		}
+		if n := nicCalls.Load(); n != 1 {
+			t.Errorf("Expected 1 machine_nics request, got %d", n)
+		}
+	})
+
+	t.Run("outside_scrape", func(t *testing.T) {
+		clusterCalls.Store(0)
+		nicCalls.Store(0)
+		testutil.CollectAndCount(first, "vergeos_nic_tx_bytes_total")
+		testutil.CollectAndCount(second, "vergeos_nic_tx_bytes_total")
+
+		if n := nicCalls.Load(); n != 2 {
+			t.Errorf("Expected 2 machine_nics requests without a scrape scope, got %d", n)
+		}
+	})
+}
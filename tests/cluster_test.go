@@ -417,3 +417,50 @@ func TestClusterCollector_OfflineCluster(t *testing.T) {
 		}
 	})
 }
+
+func TestClusterCollector_ScrapeSuccess(t *testing.T) {
+	config := DefaultMockConfig()
+	failClusters := false
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.Contains(r.URL.Path, "/clusters") {
+			if failClusters {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return true
+			}
+			WriteJSONResponse(w, []ClusterMock{})
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewClusterCollector(client, TestScrapeTimeout)
+
+	t.Run("success", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_exporter_collector_success Whether the collector completed its scrape (1=success, 0=failed)
+			# TYPE vergeos_exporter_collector_success gauge
+			vergeos_exporter_collector_success{collector="cluster"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_exporter_collector_success"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("api_failure", func(t *testing.T) {
+		failClusters = true
+		expected := `
+			# HELP vergeos_exporter_collector_success Whether the collector completed its scrape (1=success, 0=failed)
+			# TYPE vergeos_exporter_collector_success gauge
+			vergeos_exporter_collector_success{collector="cluster"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_exporter_collector_success"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+		if n := testutil.CollectAndCount(collector, "vergeos_exporter_collector_duration_seconds"); n != 1 {
+			t.Errorf("Expected 1 duration metric, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestGPUCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	nodes := []NodeMock{
+		{ID: 1, Name: "node1", Physical: true, Cluster: 1, Machine: 1},
+		{ID: 2, Name: "node2", Physical: true, Cluster: 1, Machine: 2},
+	}
+	gpus := []NodeGPUMock{
+		{Key: 1, Node: 1, Name: "gpu0", Vendor: "NVIDIA", Model: "L40S", Mode: "vgpu", VGPUProfile: "L40S-12Q", MaxInstances: 4},
+		// Identical cards in node2 share a name
+		{Key: 2, Node: 2, Name: "A100", Vendor: "NVIDIA", Model: "A100", Mode: "passthrough", Machine: 101},
+		{Key: 3, Node: 2, Name: "A100", Vendor: "NVIDIA", Model: "A100", Mode: "passthrough"},
+	}
+	vgpus := []NodeVGPUMock{
+		{Key: 1, GPU: 1, Machine: 102},
+		{Key: 2, GPU: 1, Machine: 102},
+		{Key: 3, GPU: 1, Machine: 201},
+		{Key: 4, GPU: 1},
+	}
+	vms := []VMMock{
+		{Key: 1, Name: "trainer", Machine: 101},
+		{Key: 2, Name: "inference", Machine: 102},
+	}
+	tenants := []TenantMock{{Key: 1, Name: "ai-team"}}
+	tenantNodes := []TenantNodeMock{{Key: 1, Tenant: 1, Name: "node1", Machine: 201}}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/node_gpus"):
+			WriteJSONResponse(w, gpus)
+			return true
+		case strings.Contains(r.URL.Path, "/node_vgpus"):
+			WriteJSONResponse(w, vgpus)
+			return true
+		case strings.Contains(r.URL.Path, "/nodes"):
+			WriteJSONResponse(w, nodes)
+			return true
+		case strings.Contains(r.URL.Path, "/vms"):
+			WriteJSONResponse(w, vms)
+			return true
+		case strings.Contains(r.URL.Path, "/tenant_nodes"):
+			WriteJSONResponse(w, tenantNodes)
+			return true
+		case strings.Contains(r.URL.Path, "/tenants"):
+			WriteJSONResponse(w, tenants)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewGPUCollector(client, TestScrapeTimeout)
+
+	t.Run("info", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_gpu_info Physical GPU in a node (always 1)
+			# TYPE vergeos_gpu_info gauge
+			vergeos_gpu_info{gpu="gpu0",gpu_id="1",mode="vgpu",model="L40S",node="node1",system_name="testcloud",vendor="NVIDIA"} 1
+			vergeos_gpu_info{gpu="A100",gpu_id="2",mode="passthrough",model="A100",node="node2",system_name="testcloud",vendor="NVIDIA"} 1
+			vergeos_gpu_info{gpu="A100",gpu_id="3",mode="passthrough",model="A100",node="node2",system_name="testcloud",vendor="NVIDIA"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_gpu_info"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("instances", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_gpu_instances Instances the GPU provides: 1 for passthrough, or the vGPU profile's maximum
+			# TYPE vergeos_gpu_instances gauge
+			vergeos_gpu_instances{gpu="gpu0",gpu_id="1",node="node1",profile="L40S-12Q",system_name="testcloud"} 4
+			vergeos_gpu_instances{gpu="A100",gpu_id="2",node="node2",profile="passthrough",system_name="testcloud"} 1
+			vergeos_gpu_instances{gpu="A100",gpu_id="3",node="node2",profile="passthrough",system_name="testcloud"} 1
+			# HELP vergeos_gpu_instances_assigned Instances of the GPU assigned to a VM or tenant
+			# TYPE vergeos_gpu_instances_assigned gauge
+			vergeos_gpu_instances_assigned{gpu="gpu0",gpu_id="1",node="node1",profile="L40S-12Q",system_name="testcloud"} 3
+			vergeos_gpu_instances_assigned{gpu="A100",gpu_id="2",node="node2",profile="passthrough",system_name="testcloud"} 1
+			vergeos_gpu_instances_assigned{gpu="A100",gpu_id="3",node="node2",profile="passthrough",system_name="testcloud"} 0
+			# HELP vergeos_gpu_instances_free Instances of the GPU not assigned to a VM or tenant
+			# TYPE vergeos_gpu_instances_free gauge
+			vergeos_gpu_instances_free{gpu="gpu0",gpu_id="1",node="node1",profile="L40S-12Q",system_name="testcloud"} 1
+			vergeos_gpu_instances_free{gpu="A100",gpu_id="2",node="node2",profile="passthrough",system_name="testcloud"} 0
+			vergeos_gpu_instances_free{gpu="A100",gpu_id="3",node="node2",profile="passthrough",system_name="testcloud"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_gpu_instances", "vergeos_gpu_instances_assigned", "vergeos_gpu_instances_free"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("holders", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_gpu_assigned_instances Instances of the GPU held by the VM or tenant
+			# TYPE vergeos_gpu_assigned_instances gauge
+			vergeos_gpu_assigned_instances{gpu="gpu0",gpu_id="1",holder="ai-team",holder_type="tenant",node="node1",profile="L40S-12Q",system_name="testcloud"} 1
+			vergeos_gpu_assigned_instances{gpu="gpu0",gpu_id="1",holder="inference",holder_type="vm",node="node1",profile="L40S-12Q",system_name="testcloud"} 2
+			vergeos_gpu_assigned_instances{gpu="A100",gpu_id="2",holder="trainer",holder_type="vm",node="node2",profile="passthrough",system_name="testcloud"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_gpu_assigned_instances"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"vergeos-exporter/collectors"
+)
+
+func TestLimiter_BoundsFanOut(t *testing.T) {
+	limiter := collectors.NewLimiter(2)
+	first := collectors.NewBaseCollector("first", nil, TestScrapeTimeout, collectors.WithLimiter(limiter))
+	second := collectors.NewBaseCollector("second", nil, TestScrapeTimeout, collectors.WithLimiter(limiter))
+
+	var inFlight, peak, calls atomic.Int32
+	work := func(int) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		calls.Add(1)
+	}
+
+	// Two collectors sharing one limiter stay within its bound together.
+	done := make(chan struct{})
+	go func() {
+		first.FanOut(context.Background(), 10, work)
+		close(done)
+	}()
+	second.FanOut(context.Background(), 10, work)
+	<-done
+
+	if n := calls.Load(); n != 20 {
+		t.Errorf("Expected 20 calls, got %d", n)
+	}
+	if n := peak.Load(); n > 2 {
+		t.Errorf("Expected at most 2 concurrent calls, got %d", n)
+	}
+}
+
+func TestLimiter_SkipsAfterContextDone(t *testing.T) {
+	bc := collectors.NewBaseCollector("test", nil, TestScrapeTimeout, collectors.WithLimiter(collectors.NewLimiter(1)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls atomic.Int32
+	bc.FanOut(ctx, 5, func(int) {
+		calls.Add(1)
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected 1 call before cancellation, got %d", n)
+	}
+}
@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLogCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	var requests atomic.Int32
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		if !strings.Contains(r.URL.Path, "/logs") {
+			return false
+		}
+		requests.Add(1)
+		filter := r.URL.Query().Get("filter")
+		switch {
+		case strings.Contains(filter, "timestamp gt"):
+			// First scrape: tails by time.
+			WriteJSONResponse(w, []LogMock{
+				{Key: 10, Level: "message", ObjectType: "vm", ObjectName: "web-server", Text: "VM started"},
+				{Key: 11, Level: "error", ObjectType: "node", ObjectName: "node1", Text: "Drive offline"},
+			})
+		case strings.Contains(filter, "$key gt 11"):
+			// Later scrapes: tails by key.
+			WriteJSONResponse(w, []LogMock{
+				{Key: 12, Level: "message", ObjectType: "vm", ObjectName: "db-server", Text: "VM started"},
+			})
+		default:
+			t.Errorf("Unexpected log filter %q", filter)
+			WriteJSONResponse(w, []LogMock{})
+		}
+		return true
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewLogCollector(client, TestScrapeTimeout)
+
+	expected := `
+		# HELP vergeos_log_entries_total Number of system log entries written since the exporter started
+		# TYPE vergeos_log_entries_total counter
+		vergeos_log_entries_total{level="error",object_type="node",system_name="testcloud"} 1
+		vergeos_log_entries_total{level="message",object_type="vm",system_name="testcloud"} 1
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_log_entries_total"); err != nil {
+		t.Errorf("Unexpected metric values after first scrape: %v", err)
+	}
+
+	// The second scrape adds only the new entry to the existing counts.
+	expected = `
+		# HELP vergeos_log_entries_total Number of system log entries written since the exporter started
+		# TYPE vergeos_log_entries_total counter
+		vergeos_log_entries_total{level="error",object_type="node",system_name="testcloud"} 1
+		vergeos_log_entries_total{level="message",object_type="vm",system_name="testcloud"} 2
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_log_entries_total"); err != nil {
+		t.Errorf("Unexpected metric values after second scrape: %v", err)
+	}
+
+	if n := requests.Load(); n != 2 {
+		t.Errorf("Expected 2 log requests, got %d", n)
+	}
+}
@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestNASCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	services := []NASServiceMock{
+		{Key: 1, Name: "nas01", Machine: 301},
+		{Key: 2, Name: "nas02", Machine: 302},
+	}
+	statuses := []MachineStatusMock{
+		{Key: 1, Machine: 301, Running: true, Status: "running"},
+		{Key: 2, Machine: 302, Running: false, Status: "stopped"},
+	}
+	volumes := []NASVolumeMock{
+		{Key: "vol-a", Name: "projects", Service: 1, MaxSize: 1099511627776, UsedBytes: 274877906944},
+		{Key: "vol-b", Name: "scratch", Service: 1, UsedBytes: 1073741824},
+	}
+	snapshots := []NASVolumeSnapshotMock{
+		{Key: 1, Volume: "vol-a", Name: "daily-1"},
+		{Key: 2, Volume: "vol-a", Name: "daily-2"},
+	}
+	cifsShares := []NASShareMock{
+		{Key: "s1", Volume: "vol-a", Name: "projects"},
+		{Key: "s2", Volume: "vol-a", Name: "archive"},
+	}
+	nfsShares := []NASShareMock{
+		{Key: "n1", Volume: "vol-b", Name: "scratch"},
+	}
+	syncs := []NASVolumeSyncMock{
+		{Key: "y1", Name: "projects-offsite", Service: 1, SourceVolumeName: "projects", DestinationVolumeName: "projects-copy", Enabled: true, Status: "error", LastRun: 1700000000},
+		{Key: "y2", Name: "scratch-copy", Service: 1, SourceVolumeName: "scratch", DestinationVolumeName: "scratch-copy", Enabled: false, Status: "offline"},
+	}
+
+	var statusesDown, cifsDown atomic.Bool
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/vm_services"):
+			WriteJSONResponse(w, services)
+			return true
+		case strings.Contains(r.URL.Path, "/volume_snapshots"):
+			WriteJSONResponse(w, snapshots)
+			return true
+		case strings.Contains(r.URL.Path, "/volume_syncs"):
+			WriteJSONResponse(w, syncs)
+			return true
+		case strings.Contains(r.URL.Path, "/volumes"):
+			WriteJSONResponse(w, volumes)
+			return true
+		case strings.Contains(r.URL.Path, "/volume_cifs_shares"):
+			if cifsDown.Load() {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return true
+			}
+			WriteJSONResponse(w, cifsShares)
+			return true
+		case strings.Contains(r.URL.Path, "/volume_nfs_shares"):
+			WriteJSONResponse(w, nfsShares)
+			return true
+		case strings.Contains(r.URL.Path, "/machine_status"):
+			if statusesDown.Load() {
+				http.Error(w, "internal error", http.StatusInternalServerError)
+				return true
+			}
+			WriteJSONResponse(w, statuses)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewNASCollector(client, TestScrapeTimeout)
+
+	t.Run("service_running", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_nas_service_running Whether the NAS service is running (1=running, 0=not running)
+			# TYPE vergeos_nas_service_running gauge
+			vergeos_nas_service_running{nas_service="nas01",system_name="testcloud"} 1
+			vergeos_nas_service_running{nas_service="nas02",system_name="testcloud"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_nas_service_running"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("volume_space", func(t *testing.T) {
+		// The unlimited scratch volume has no free-space series.
+		expected := `
+			# HELP vergeos_nas_volume_free_bytes Free space on the NAS volume in bytes, for volumes with a maximum size
+			# TYPE vergeos_nas_volume_free_bytes gauge
+			vergeos_nas_volume_free_bytes{nas_service="nas01",system_name="testcloud",volume="projects"} 8.24633720832e+11
+			# HELP vergeos_nas_volume_size_bytes Configured maximum size of the NAS volume in bytes (0=unlimited)
+			# TYPE vergeos_nas_volume_size_bytes gauge
+			vergeos_nas_volume_size_bytes{nas_service="nas01",system_name="testcloud",volume="projects"} 1.099511627776e+12
+			vergeos_nas_volume_size_bytes{nas_service="nas01",system_name="testcloud",volume="scratch"} 0
+			# HELP vergeos_nas_volume_used_bytes Used space on the NAS volume in bytes
+			# TYPE vergeos_nas_volume_used_bytes gauge
+			vergeos_nas_volume_used_bytes{nas_service="nas01",system_name="testcloud",volume="projects"} 2.74877906944e+11
+			vergeos_nas_volume_used_bytes{nas_service="nas01",system_name="testcloud",volume="scratch"} 1.073741824e+09
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_nas_volume_size_bytes", "vergeos_nas_volume_used_bytes", "vergeos_nas_volume_free_bytes"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("snapshots_and_shares", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_nas_volume_shares Number of shares of the NAS volume by protocol
+			# TYPE vergeos_nas_volume_shares gauge
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="cifs",system_name="testcloud",volume="projects"} 2
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="nfs",system_name="testcloud",volume="projects"} 0
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="cifs",system_name="testcloud",volume="scratch"} 0
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="nfs",system_name="testcloud",volume="scratch"} 1
+			# HELP vergeos_nas_volume_snapshots Number of snapshots of the NAS volume
+			# TYPE vergeos_nas_volume_snapshots gauge
+			vergeos_nas_volume_snapshots{nas_service="nas01",system_name="testcloud",volume="projects"} 2
+			vergeos_nas_volume_snapshots{nas_service="nas01",system_name="testcloud",volume="scratch"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_nas_volume_snapshots", "vergeos_nas_volume_shares"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("syncs", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_nas_volume_sync_error Whether the volume sync job is in an error state (1=error, 0=ok)
+			# TYPE vergeos_nas_volume_sync_error gauge
+			vergeos_nas_volume_sync_error{destination_volume="projects-copy",nas_service="nas01",source_volume="projects",sync_name="projects-offsite",system_name="testcloud"} 1
+			vergeos_nas_volume_sync_error{destination_volume="scratch-copy",nas_service="nas01",source_volume="scratch",sync_name="scratch-copy",system_name="testcloud"} 0
+			# HELP vergeos_nas_volume_sync_last_run_timestamp_seconds Unix time the volume sync job's last run finished
+			# TYPE vergeos_nas_volume_sync_last_run_timestamp_seconds gauge
+			vergeos_nas_volume_sync_last_run_timestamp_seconds{destination_volume="projects-copy",nas_service="nas01",source_volume="projects",sync_name="projects-offsite",system_name="testcloud"} 1.7e+09
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_nas_volume_sync_error", "vergeos_nas_volume_sync_last_run_timestamp_seconds"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("statuses_unavailable", func(t *testing.T) {
+		// Without machine statuses the running state is unknown, so it is
+		// skipped rather than reported as 0 for every service.
+		statusesDown.Store(true)
+		defer statusesDown.Store(false)
+
+		if n := testutil.CollectAndCount(collector, "vergeos_nas_service_running"); n != 0 {
+			t.Errorf("Expected no service running series, got %d", n)
+		}
+		if n := testutil.CollectAndCount(collector, "vergeos_nas_volume_used_bytes"); n != 2 {
+			t.Errorf("Expected 2 volume used series, got %d", n)
+		}
+	})
+	t.Run("shares_unavailable", func(t *testing.T) {
+		// A failed share list skips only that protocol's share counts; volume
+		// space, snapshots and the other protocol are still reported.
+		cifsDown.Store(true)
+		defer cifsDown.Store(false)
+
+		expected := `
+			# HELP vergeos_nas_volume_shares Number of shares of the NAS volume by protocol
+			# TYPE vergeos_nas_volume_shares gauge
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="nfs",system_name="testcloud",volume="projects"} 0
+			vergeos_nas_volume_shares{nas_service="nas01",protocol="nfs",system_name="testcloud",volume="scratch"} 1
+			# HELP vergeos_nas_volume_used_bytes Used space on the NAS volume in bytes
+			# TYPE vergeos_nas_volume_used_bytes gauge
+			vergeos_nas_volume_used_bytes{nas_service="nas01",system_name="testcloud",volume="projects"} 2.74877906944e+11
+			vergeos_nas_volume_used_bytes{nas_service="nas01",system_name="testcloud",volume="scratch"} 1.073741824e+09
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_nas_volume_used_bytes", "vergeos_nas_volume_shares"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+		if n := testutil.CollectAndCount(collector, "vergeos_nas_volume_snapshots"); n != 2 {
+			t.Errorf("Expected 2 volume snapshot series, got %d", n)
+		}
+	})
+}
@@ -125,7 +125,7 @@ func TestNetworkCollector_Describe(t *testing.T) {
 	client := CreateTestSDKClient(t, mockServer.URL)
 	collector := collectors.NewNetworkCollector(client, TestScrapeTimeout)
 
-	// Verify Describe sends exactly 5 descriptors
+	// Verify Describe sends exactly 5 NIC descriptors plus the 2 scrape descriptors
 	ch := make(chan *prometheus.Desc, 10)
 	collector.Describe(ch)
 	close(ch)
@@ -135,8 +135,8 @@ func TestNetworkCollector_Describe(t *testing.T) {
 		count++
 	}
 
-	if count != 5 {
-		t.Errorf("Expected 5 descriptors, got %d", count)
+	if count != 7 {
+		t.Errorf("Expected 7 descriptors, got %d", count)
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"vergeos-exporter/collectors"
 
@@ -17,9 +18,12 @@ import (
 func TestNodeCollector(t *testing.T) {
 	config := DefaultMockConfig()
 
+	now := time.Now().Unix()
 	nodes := []NodeMock{
-		{ID: 1, Name: "node1", Physical: true, Cluster: 1, Machine: 101, IPMIStatus: "ok", RAM: 65536, VMRAM: 32768, VMStatsTotals: &NodeVMStatsTotalsMock{RunningCores: 24, RunningRAM: 49152}},
-		{ID: 2, Name: "node2", Physical: true, Cluster: 1, Machine: 102, IPMIStatus: "offline", RAM: 65536, VMRAM: 16384, VMStatsTotals: &NodeVMStatsTotalsMock{RunningCores: 8, RunningRAM: 16384}},
+		{ID: 1, Name: "node1", Physical: true, Cluster: 1, Machine: 101, IPMIStatus: "ok", RAM: 65536, VMRAM: 32768, VMStatsTotals: &NodeVMStatsTotalsMock{RunningCores: 24, RunningRAM: 49152},
+			Vendor: "Supermicro", Model: "SYS-1029U", BIOSVersion: "3.4", BMCVersion: "1.73.14", CPUModel: "Intel Xeon Gold 6230", Cores: 40, Threads: 80, Version: "4.13.1", Started: now - 86400},
+		{ID: 2, Name: "node2", Physical: true, Cluster: 1, Machine: 102, IPMIStatus: "offline", RAM: 65536, VMRAM: 16384, VMStatsTotals: &NodeVMStatsTotalsMock{RunningCores: 8, RunningRAM: 16384},
+			Vendor: "Supermicro", Model: "SYS-1029U", BIOSVersion: "3.2", BMCVersion: "1.71.11", CPUModel: "Intel Xeon Gold 6230", Cores: 40, Threads: 80, Version: "4.13.0", Maintenance: true},
 	}
 
 	clusters := []ClusterMock{
@@ -31,11 +35,26 @@ func TestNodeCollector(t *testing.T) {
 		102: {Key: 2, Machine: 102, TotalCPU: 30, UserCPU: 10, SystemCPU: 15, IOWaitCPU: 5, RAMUsed: 32000, RAMPct: 49, CoreUsageList: json.RawMessage(`[5.0, 15.0]`), CoreTemp: 42, CoreTempTop: 48},
 	}
 
+	// node2's BMC is offline, so it has no sensors
+	sensors := []NodeIPMISensorMock{
+		{Key: 1, Node: 1, Name: "FAN1", Type: "Fan", Value: 5400, Unit: "RPM", Status: "ok"},
+		{Key: 2, Node: 1, Name: "FAN2", Type: "Fan", Value: 0, Unit: "RPM", Status: "cr"},
+		{Key: 3, Node: 1, Name: "Inlet Temp", Type: "Temperature", Value: 24, Unit: "degrees C", Status: "ok"},
+		{Key: 4, Node: 1, Name: "PS2 Status", Type: "Power Supply", Status: "cr"},
+		{Key: 5, Node: 1, Name: "FAN3", Type: "Fan", Unit: "RPM", Status: "ns"},
+		// iDRAC-style BMCs name both CPU temperature sensors "Temp"
+		{Key: 6, Node: 1, Name: "Temp", Type: "Temperature", Value: 58, Unit: "degrees C", Status: "ok"},
+		{Key: 7, Node: 1, Name: "Temp", Type: "Temperature", Value: 91, Unit: "degrees C", Status: "cr"},
+	}
+
 	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
 		switch {
 		case strings.Contains(r.URL.Path, "/clusters"):
 			WriteJSONResponse(w, clusters)
 			return true
+		case strings.Contains(r.URL.Path, "/node_ipmi_sensors"):
+			WriteJSONResponse(w, sensors)
+			return true
 		case strings.Contains(r.URL.Path, "/nodes") && strings.Contains(r.URL.RawQuery, "physical"):
 			WriteJSONResponse(w, nodes)
 			return true
@@ -259,6 +278,57 @@ func TestNodeCollector(t *testing.T) {
 			t.Errorf("Unexpected metric values: %v", err)
 		}
 	})
+
+	t.Run("sensors", func(t *testing.T) {
+		// The PSU has no reading, FAN3 reports no status at all, and the two
+		// "Temp" sensors are told apart by their ID
+		expected := `
+			# HELP vergeos_node_sensor_ok Whether the node's IPMI sensor reports an ok status (1=ok, 0=warning, critical or failed)
+			# TYPE vergeos_node_sensor_ok gauge
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="FAN1",sensor_id="1",system_name="testcloud",type="fan"} 1
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="FAN2",sensor_id="2",system_name="testcloud",type="fan"} 0
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="Inlet Temp",sensor_id="3",system_name="testcloud",type="temperature"} 1
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="PS2 Status",sensor_id="4",system_name="testcloud",type="power supply"} 0
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="Temp",sensor_id="6",system_name="testcloud",type="temperature"} 1
+			vergeos_node_sensor_ok{cluster="cluster1",node_name="node1",sensor="Temp",sensor_id="7",system_name="testcloud",type="temperature"} 0
+			# HELP vergeos_node_sensor_value Reading of the node's IPMI sensor, in the sensor's unit
+			# TYPE vergeos_node_sensor_value gauge
+			vergeos_node_sensor_value{cluster="cluster1",node_name="node1",sensor="FAN1",sensor_id="1",system_name="testcloud",type="fan",unit="rpm"} 5400
+			vergeos_node_sensor_value{cluster="cluster1",node_name="node1",sensor="FAN2",sensor_id="2",system_name="testcloud",type="fan",unit="rpm"} 0
+			vergeos_node_sensor_value{cluster="cluster1",node_name="node1",sensor="Inlet Temp",sensor_id="3",system_name="testcloud",type="temperature",unit="celsius"} 24
+			vergeos_node_sensor_value{cluster="cluster1",node_name="node1",sensor="Temp",sensor_id="6",system_name="testcloud",type="temperature",unit="celsius"} 58
+			vergeos_node_sensor_value{cluster="cluster1",node_name="node1",sensor="Temp",sensor_id="7",system_name="testcloud",type="temperature",unit="celsius"} 91
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_node_sensor_ok", "vergeos_node_sensor_value"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("node_info", func(t *testing.T) {
+		// node2 lags node1 on BIOS, BMC and VergeOS versions
+		expected := `
+			# HELP vergeos_node_info Hardware, firmware and software inventory of the node (always 1)
+			# TYPE vergeos_node_info gauge
+			vergeos_node_info{bios_version="3.4",bmc_version="1.73.14",cluster="cluster1",cores="40",cpu_model="Intel Xeon Gold 6230",maintenance_mode="false",model="SYS-1029U",node_name="node1",system_name="testcloud",threads="80",vendor="Supermicro",vergeos_version="4.13.1"} 1
+			vergeos_node_info{bios_version="3.2",bmc_version="1.71.11",cluster="cluster1",cores="40",cpu_model="Intel Xeon Gold 6230",maintenance_mode="true",model="SYS-1029U",node_name="node2",system_name="testcloud",threads="80",vendor="Supermicro",vergeos_version="4.13.0"} 1
+			# HELP vergeos_node_maintenance_mode Whether the node is in maintenance mode (1=maintenance, 0=normal)
+			# TYPE vergeos_node_maintenance_mode gauge
+			vergeos_node_maintenance_mode{cluster="cluster1",node_name="node1",system_name="testcloud"} 0
+			vergeos_node_maintenance_mode{cluster="cluster1",node_name="node2",system_name="testcloud"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_node_info", "vergeos_node_maintenance_mode"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("uptime", func(t *testing.T) {
+		// node2 has no start time, so reports no uptime
+		if n := testutil.CollectAndCount(collector, "vergeos_node_uptime_seconds"); n != 1 {
+			t.Errorf("Expected 1 node uptime series, got %d", n)
+		}
+	})
 }
 
 func TestNodeCollector_StaleMetrics(t *testing.T) {
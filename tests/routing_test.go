@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRoutingCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	clusters := []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}}
+	vnets := []VNetMock{
+		{Key: 1, Name: "External", Enabled: true, Running: true, Cluster: 1, Type: "external"},
+	}
+	bgp := []VNetBGPNeighborMock{
+		{Key: 1, VNet: 1, Neighbor: "198.51.100.1", State: "Established", Uptime: 86400, PrefixesReceived: 950000, PrefixesAdvertised: 4},
+		{Key: 2, VNet: 1, Neighbor: "198.51.100.5", State: "Active", PrefixesReceived: 12},
+	}
+	ospf := []VNetOSPFNeighborMock{
+		{Key: 1, VNet: 1, NeighborID: "10.255.0.1", State: "Full/DR", Uptime: 3600},
+		{Key: 2, VNet: 1, NeighborID: "10.255.0.2", State: "ExStart/DROther", Uptime: 10},
+		// One router adjacent over two interfaces reports as one neighbor
+		{Key: 3, VNet: 1, NeighborID: "10.255.0.3", State: "Full/BDR", Uptime: 7200},
+		{Key: 4, VNet: 1, NeighborID: "10.255.0.3", State: "2-Way/DROther", Uptime: 600},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, clusters)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_bgp_neighbors"):
+			WriteJSONResponse(w, bgp)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_ospf_neighbors"):
+			WriteJSONResponse(w, ospf)
+			return true
+		case strings.Contains(r.URL.Path, "/vnets"):
+			WriteJSONResponse(w, vnets)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewRoutingCollector(client, TestScrapeTimeout)
+
+	t.Run("neighbor_up", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_routing_neighbor_up Whether the session with the routing neighbor is established (1=up, 0=down)
+			# TYPE vergeos_routing_neighbor_up gauge
+			vergeos_routing_neighbor_up{cluster="cluster1",layer2_type="",neighbor="198.51.100.1",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 1
+			vergeos_routing_neighbor_up{cluster="cluster1",layer2_type="",neighbor="198.51.100.5",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 0
+			vergeos_routing_neighbor_up{cluster="cluster1",layer2_type="",neighbor="10.255.0.1",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 1
+			vergeos_routing_neighbor_up{cluster="cluster1",layer2_type="",neighbor="10.255.0.2",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 0
+			vergeos_routing_neighbor_up{cluster="cluster1",layer2_type="",neighbor="10.255.0.3",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_routing_neighbor_up"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("uptime_and_prefixes", func(t *testing.T) {
+		// Down neighbors have no uptime or prefix series
+		expected := `
+			# HELP vergeos_routing_prefixes_advertised Prefixes advertised to the BGP neighbor
+			# TYPE vergeos_routing_prefixes_advertised gauge
+			vergeos_routing_prefixes_advertised{cluster="cluster1",layer2_type="",neighbor="198.51.100.1",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 4
+			# HELP vergeos_routing_prefixes_received Prefixes received from the BGP neighbor
+			# TYPE vergeos_routing_prefixes_received gauge
+			vergeos_routing_prefixes_received{cluster="cluster1",layer2_type="",neighbor="198.51.100.1",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 950000
+			# HELP vergeos_routing_session_uptime_seconds Seconds the session with the routing neighbor has been established
+			# TYPE vergeos_routing_session_uptime_seconds gauge
+			vergeos_routing_session_uptime_seconds{cluster="cluster1",layer2_type="",neighbor="198.51.100.1",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 86400
+			vergeos_routing_session_uptime_seconds{cluster="cluster1",layer2_type="",neighbor="10.255.0.1",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 3600
+			vergeos_routing_session_uptime_seconds{cluster="cluster1",layer2_type="",neighbor="10.255.0.3",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 600
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_routing_session_uptime_seconds", "vergeos_routing_prefixes_received", "vergeos_routing_prefixes_advertised"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("per_vnet_counts", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_routing_neighbors Number of configured routing neighbors on the virtual network by protocol
+			# TYPE vergeos_routing_neighbors gauge
+			vergeos_routing_neighbors{cluster="cluster1",layer2_type="",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 2
+			vergeos_routing_neighbors{cluster="cluster1",layer2_type="",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 3
+			# HELP vergeos_routing_neighbors_up Number of routing neighbors on the virtual network with an established session by protocol
+			# TYPE vergeos_routing_neighbors_up gauge
+			vergeos_routing_neighbors_up{cluster="cluster1",layer2_type="",protocol="bgp",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 1
+			vergeos_routing_neighbors_up{cluster="cluster1",layer2_type="",protocol="ospf",system_name="testcloud",type="external",vnet_id="1",vnet_name="External"} 2
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_routing_neighbors", "vergeos_routing_neighbors_up"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+}
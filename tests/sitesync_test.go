@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSiteSyncCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	sites := []SiteMock{
+		{Key: 1, Name: "dr-west"},
+		{Key: 2, Name: "hq-east"},
+	}
+	outgoing := []SiteSyncOutgoingMock{
+		{Key: 1, Name: "to-west", Site: 1, Enabled: true, Status: "syncing", LastRunStart: 1700000000, LastRunFinish: 1700000600, LastRunBytes: 5368709120, Throttle: 125000000},
+		{Key: 2, Name: "to-west-archive", Site: 1, Enabled: false, Status: "offline"},
+	}
+	queue := []SiteSyncOutgoingQueueMock{
+		{Key: 1, SiteSync: 1},
+		{Key: 2, SiteSync: 1},
+		{Key: 3, SiteSync: 1},
+	}
+	incoming := []SiteSyncIncomingMock{
+		{Key: 1, Name: "from-east", Site: 2, Enabled: true, Status: "error", LastRunStart: 1700001000, LastRunFinish: 1700001200, LastRunBytes: 1048576},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/site_syncs_outgoing_queue"):
+			WriteJSONResponse(w, queue)
+			return true
+		case strings.Contains(r.URL.Path, "/site_syncs_outgoing"):
+			WriteJSONResponse(w, outgoing)
+			return true
+		case strings.Contains(r.URL.Path, "/site_syncs_incoming"):
+			WriteJSONResponse(w, incoming)
+			return true
+		case strings.Contains(r.URL.Path, "/sites"):
+			WriteJSONResponse(w, sites)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewSiteSyncCollector(client, TestScrapeTimeout)
+
+	t.Run("state", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_site_sync_enabled Whether the site sync is enabled (1=enabled, 0=disabled)
+			# TYPE vergeos_site_sync_enabled gauge
+			vergeos_site_sync_enabled{direction="incoming",remote_site="hq-east",sync_name="from-east",system_name="testcloud"} 1
+			vergeos_site_sync_enabled{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 1
+			vergeos_site_sync_enabled{direction="outgoing",remote_site="dr-west",sync_name="to-west-archive",system_name="testcloud"} 0
+			# HELP vergeos_site_sync_error Whether the site sync is in an error state (1=error, 0=ok)
+			# TYPE vergeos_site_sync_error gauge
+			vergeos_site_sync_error{direction="incoming",remote_site="hq-east",sync_name="from-east",system_name="testcloud"} 1
+			vergeos_site_sync_error{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 0
+			vergeos_site_sync_error{direction="outgoing",remote_site="dr-west",sync_name="to-west-archive",system_name="testcloud"} 0
+			# HELP vergeos_site_sync_syncing Whether the site sync is transferring (1=syncing, 0=idle)
+			# TYPE vergeos_site_sync_syncing gauge
+			vergeos_site_sync_syncing{direction="incoming",remote_site="hq-east",sync_name="from-east",system_name="testcloud"} 0
+			vergeos_site_sync_syncing{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 1
+			vergeos_site_sync_syncing{direction="outgoing",remote_site="dr-west",sync_name="to-west-archive",system_name="testcloud"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_site_sync_enabled", "vergeos_site_sync_error", "vergeos_site_sync_syncing"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("last_run", func(t *testing.T) {
+		// The never-run archive sync has no last-run series.
+		expected := `
+			# HELP vergeos_site_sync_last_run_finish_timestamp_seconds Unix time the site sync's last run finished
+			# TYPE vergeos_site_sync_last_run_finish_timestamp_seconds gauge
+			vergeos_site_sync_last_run_finish_timestamp_seconds{direction="incoming",remote_site="hq-east",sync_name="from-east",system_name="testcloud"} 1.7000012e+09
+			vergeos_site_sync_last_run_finish_timestamp_seconds{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 1.7000006e+09
+			# HELP vergeos_site_sync_last_run_transferred_bytes Bytes transferred by the site sync's last run
+			# TYPE vergeos_site_sync_last_run_transferred_bytes gauge
+			vergeos_site_sync_last_run_transferred_bytes{direction="incoming",remote_site="hq-east",sync_name="from-east",system_name="testcloud"} 1.048576e+06
+			vergeos_site_sync_last_run_transferred_bytes{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 5.36870912e+09
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_site_sync_last_run_finish_timestamp_seconds", "vergeos_site_sync_last_run_transferred_bytes"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("outgoing_queue_and_throttle", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_site_sync_queued_snapshots Number of snapshots queued to send on the outgoing site sync
+			# TYPE vergeos_site_sync_queued_snapshots gauge
+			vergeos_site_sync_queued_snapshots{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 3
+			vergeos_site_sync_queued_snapshots{direction="outgoing",remote_site="dr-west",sync_name="to-west-archive",system_name="testcloud"} 0
+			# HELP vergeos_site_sync_throttle_bytes_per_second Bandwidth limit of the outgoing site sync in bytes per second (0=unlimited)
+			# TYPE vergeos_site_sync_throttle_bytes_per_second gauge
+			vergeos_site_sync_throttle_bytes_per_second{direction="outgoing",remote_site="dr-west",sync_name="to-west",system_name="testcloud"} 1.25e+08
+			vergeos_site_sync_throttle_bytes_per_second{direction="outgoing",remote_site="dr-west",sync_name="to-west-archive",system_name="testcloud"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_site_sync_queued_snapshots", "vergeos_site_sync_throttle_bytes_per_second"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("name_filter", func(t *testing.T) {
+		filtered := collectors.NewSiteSyncCollector(client, TestScrapeTimeout, collectors.WithNameFilter(collectors.NameFilter{
+			Include: regexp.MustCompile(`^to-`),
+		}))
+		if n := testutil.CollectAndCount(filtered, "vergeos_site_sync_enabled"); n != 2 {
+			t.Errorf("Expected 2 filtered syncs, got %d", n)
+		}
+	})
+}
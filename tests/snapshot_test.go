@@ -0,0 +1,165 @@
+package tests
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestSnapshotCollector(t *testing.T) {
+	config := DefaultMockConfig()
+	now := time.Now().Unix()
+
+	vms := []VMMock{
+		{Key: 1, Name: "web-server", Machine: 101, Cluster: 1, SnapshotProfile: 1},
+		{Key: 2, Name: "db-server", Machine: 102, Cluster: 1, SnapshotProfile: 1},
+		{Key: 3, Name: "scratch", Machine: 103, Cluster: 1},
+	}
+	machineSnapshots := []MachineSnapshotMock{
+		{Key: 1, Machine: 101, Name: "hourly-1", Created: now - 1800},
+		{Key: 2, Machine: 101, Name: "daily-1", Created: now - 3*86400},
+		{Key: 3, Machine: 102, Name: "hourly-1", Created: now - 5*3600},
+	}
+	profiles := []SnapshotProfileMock{
+		{Key: 1, Name: "Hourly"},
+	}
+	periods := []SnapshotProfilePeriodMock{
+		{Key: 1, Profile: 1, Name: "Daily", Frequency: "daily"},
+		{Key: 2, Profile: 1, Name: "Hourly", Frequency: "hourly"},
+	}
+	cloudSnapshots := []CloudSnapshotMock{
+		{Key: 1, Name: "nightly-1", Created: now - 2*3600, Expires: now + 7*86400, Status: "complete"},
+		{Key: 2, Name: "nightly-2", Created: now - 600, Status: "creating"},
+		{Key: 3, Name: "nightly-0", Created: now - 26*3600, Expires: now + 6*86400, Status: "complete"},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/vms"):
+			WriteJSONResponse(w, vms)
+			return true
+		case strings.Contains(r.URL.Path, "/machine_snapshots"):
+			WriteJSONResponse(w, machineSnapshots)
+			return true
+		case strings.Contains(r.URL.Path, "/snapshot_profile_periods"):
+			WriteJSONResponse(w, periods)
+			return true
+		case strings.Contains(r.URL.Path, "/snapshot_profiles"):
+			WriteJSONResponse(w, profiles)
+			return true
+		case strings.Contains(r.URL.Path, "/cloud_snapshots"):
+			WriteJSONResponse(w, cloudSnapshots)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewSnapshotCollector(client, TestScrapeTimeout)
+
+	t.Run("vm_snapshot_counts", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vm_snapshots_total Number of snapshots of the VM
+			# TYPE vergeos_vm_snapshots_total gauge
+			vergeos_vm_snapshots_total{system_name="testcloud",vm_id="1",vm_name="web-server"} 2
+			vergeos_vm_snapshots_total{system_name="testcloud",vm_id="2",vm_name="db-server"} 1
+			vergeos_vm_snapshots_total{system_name="testcloud",vm_id="3",vm_name="scratch"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_snapshots_total"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("vm_snapshot_profile_compliance", func(t *testing.T) {
+		// web-server's newest snapshot is 30 minutes old against an hourly
+		// profile; db-server's is 5 hours old. scratch has no profile.
+		expected := `
+			# HELP vergeos_vm_snapshot_profile_compliant Whether the VM's newest snapshot is recent enough for its snapshot profile (1=compliant, 0=overdue or no snapshot)
+			# TYPE vergeos_vm_snapshot_profile_compliant gauge
+			vergeos_vm_snapshot_profile_compliant{profile="Hourly",system_name="testcloud",vm_id="1",vm_name="web-server"} 1
+			vergeos_vm_snapshot_profile_compliant{profile="Hourly",system_name="testcloud",vm_id="2",vm_name="db-server"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_snapshot_profile_compliant"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("cloud_snapshots", func(t *testing.T) {
+		// The newest snapshot is still being created, so the last completed
+		// one is nightly-1.
+		expected := fmt.Sprintf(`
+			# HELP vergeos_cloud_snapshots_total Number of cloud snapshots
+			# TYPE vergeos_cloud_snapshots_total gauge
+			vergeos_cloud_snapshots_total{system_name="testcloud"} 3
+			# HELP vergeos_cloud_snapshot_last_success_timestamp_seconds Unix time the newest completed cloud snapshot was taken
+			# TYPE vergeos_cloud_snapshot_last_success_timestamp_seconds gauge
+			vergeos_cloud_snapshot_last_success_timestamp_seconds{system_name="testcloud"} %d
+			# HELP vergeos_cloud_snapshot_last_success_expires_timestamp_seconds Unix time the newest completed cloud snapshot expires (0=never)
+			# TYPE vergeos_cloud_snapshot_last_success_expires_timestamp_seconds gauge
+			vergeos_cloud_snapshot_last_success_expires_timestamp_seconds{system_name="testcloud"} %d
+		`, now-2*3600, now+7*86400)
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_cloud_snapshots_total",
+			"vergeos_cloud_snapshot_last_success_timestamp_seconds",
+			"vergeos_cloud_snapshot_last_success_expires_timestamp_seconds",
+		); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("vm_snapshot_ages", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(collector)
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Failed to gather metrics: %v", err)
+		}
+
+		ages := map[string]float64{}
+		for _, mf := range families {
+			name := mf.GetName()
+			if name != "vergeos_vm_snapshot_newest_age_seconds" && name != "vergeos_vm_snapshot_oldest_age_seconds" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				ages[name+"/"+labelValue(m, "vm_name")] = m.GetGauge().GetValue()
+			}
+		}
+
+		// VMs without snapshots report no ages.
+		if len(ages) != 4 {
+			t.Errorf("Expected 4 age series, got %d: %v", len(ages), ages)
+		}
+		want := map[string]float64{
+			"vergeos_vm_snapshot_newest_age_seconds/web-server": 1800,
+			"vergeos_vm_snapshot_oldest_age_seconds/web-server": 3 * 86400,
+			"vergeos_vm_snapshot_newest_age_seconds/db-server":  5 * 3600,
+			"vergeos_vm_snapshot_oldest_age_seconds/db-server":  5 * 3600,
+		}
+		for key, w := range want {
+			if got, ok := ages[key]; !ok || math.Abs(got-w) > 60 {
+				t.Errorf("%s = %v, want about %v", key, got, w)
+			}
+		}
+	})
+}
+
+// labelValue returns the value of the named label on m, or "" if absent.
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestTaskCollector(t *testing.T) {
+	config := DefaultMockConfig()
+	now := time.Now().Unix()
+
+	var mutex sync.Mutex
+	tasks := []TaskMock{
+		{Key: 1, Name: "Import web-server", Type: "import", Status: "running", Progress: 42.5, Started: now - 3600},
+		{Key: 2, Name: "Migrate db-server", Type: "migration", Status: "running", Progress: 10, Started: now - 600},
+		{Key: 3, Name: "Import old", Type: "import", Status: "error", Finished: now - 86400},
+		{Key: 4, Name: "Update", Type: "update", Status: "idle"},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		if strings.Contains(r.URL.Path, "/tasks") {
+			mutex.Lock()
+			defer mutex.Unlock()
+			WriteJSONResponse(w, tasks)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewTaskCollector(client, TestScrapeTimeout)
+
+	t.Run("running", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_task_progress_pct Progress of the running task as a percentage
+			# TYPE vergeos_task_progress_pct gauge
+			vergeos_task_progress_pct{system_name="testcloud",task_id="1",task_name="Import web-server",type="import"} 42.5
+			vergeos_task_progress_pct{system_name="testcloud",task_id="2",task_name="Migrate db-server",type="migration"} 10
+			# HELP vergeos_tasks_running Number of running tasks by type
+			# TYPE vergeos_tasks_running gauge
+			vergeos_tasks_running{system_name="testcloud",type="import"} 1
+			vergeos_tasks_running{system_name="testcloud",type="migration"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_task_progress_pct", "vergeos_tasks_running"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+		if n := testutil.CollectAndCount(collector, "vergeos_task_age_seconds"); n != 2 {
+			t.Errorf("Expected 2 task age series, got %d", n)
+		}
+	})
+
+	t.Run("failed_since_start", func(t *testing.T) {
+		// The failure listed before the first scrape isn't counted.
+		if n := testutil.CollectAndCount(collector, "vergeos_tasks_failed_total"); n != 0 {
+			t.Errorf("Expected no failed task series, got %d", n)
+		}
+
+		// The migration fails, then the old import is retried and fails again.
+		mutex.Lock()
+		tasks[1].Status = "error"
+		tasks[1].Finished = now
+		tasks[2].Finished = now
+		mutex.Unlock()
+
+		expected := `
+			# HELP vergeos_tasks_failed_total Number of tasks that failed since the exporter started, by type
+			# TYPE vergeos_tasks_failed_total counter
+			vergeos_tasks_failed_total{system_name="testcloud",type="import"} 1
+			vergeos_tasks_failed_total{system_name="testcloud",type="migration"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_tasks_failed_total"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+
+		// Failures are counted once.
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_tasks_failed_total"); err != nil {
+			t.Errorf("Unexpected metric values on rescrape: %v", err)
+		}
+	})
+}
@@ -192,6 +192,27 @@ type NodeMock struct {
 	RAM           int64                  `json:"ram"`
 	VMRAM         int64                  `json:"vm_ram"`
 	VMStatsTotals *NodeVMStatsTotalsMock `json:"vm_stats_totals,omitempty"`
+	Vendor        string                 `json:"vendor,omitempty"`
+	Model         string                 `json:"model,omitempty"`
+	BIOSVersion   string                 `json:"bios_version,omitempty"`
+	BMCVersion    string                 `json:"bmc_version,omitempty"`
+	CPUModel      string                 `json:"cpu_model,omitempty"`
+	Cores         int                    `json:"cores,omitempty"`
+	Threads       int                    `json:"threads,omitempty"`
+	Version       string                 `json:"version,omitempty"`
+	Maintenance   bool                   `json:"maintenance,omitempty"`
+	Started       int64                  `json:"started,omitempty"`
+}
+
+// NodeIPMISensorMock represents a mock IPMI sensor reading of a node
+type NodeIPMISensorMock struct {
+	Key    int     `json:"$key"`
+	Node   int     `json:"node"`
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Value  float64 `json:"value,omitempty"`
+	Unit   string  `json:"unit,omitempty"`
+	Status string  `json:"status"`
 }
 
 // ClusterMock represents a mock cluster
@@ -388,6 +409,93 @@ type VMMock struct {
 	Enabled    bool   `json:"enabled"`
 	CPUCores   int    `json:"cpu_cores"`
 	RAM        int    `json:"ram"`
+
+	SnapshotProfile int `json:"snapshot_profile,omitempty"`
+
+	MachineType string `json:"machine_type,omitempty"`
+	OSFamily    string `json:"os_family,omitempty"`
+	HAGroup     string `json:"ha_group,omitempty"`
+	BootOrder   string `json:"boot_order,omitempty"`
+	CPUType     string `json:"cpu_type,omitempty"`
+	UEFI        bool   `json:"uefi,omitempty"`
+	SecureBoot  bool   `json:"secure_boot,omitempty"`
+	Balloon     bool   `json:"balloon,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// NodeGPUMock represents a mock physical GPU in a node
+type NodeGPUMock struct {
+	Key          int    `json:"$key"`
+	Node         int    `json:"node"`
+	Name         string `json:"name"`
+	Vendor       string `json:"vendor"`
+	Model        string `json:"model"`
+	Mode         string `json:"mode"`
+	VGPUProfile  string `json:"vgpu_profile,omitempty"`
+	MaxInstances int    `json:"max_instances,omitempty"`
+	Machine      int    `json:"machine,omitempty"`
+}
+
+// NodeVGPUMock represents a mock vGPU instance created on a GPU
+type NodeVGPUMock struct {
+	Key     int `json:"$key"`
+	GPU     int `json:"gpu"`
+	Machine int `json:"machine,omitempty"`
+}
+
+// NASServiceMock represents a mock NAS service
+type NASServiceMock struct {
+	Key     int    `json:"$key"`
+	Name    string `json:"name"`
+	Machine int    `json:"machine"`
+}
+
+// NASVolumeMock represents a mock NAS volume. Volume keys are strings.
+type NASVolumeMock struct {
+	Key       string `json:"$key"`
+	Name      string `json:"name"`
+	Service   int    `json:"service"`
+	MaxSize   int64  `json:"maxsize"`
+	UsedBytes int64  `json:"used_bytes"`
+}
+
+// NASVolumeSnapshotMock represents a mock NAS volume snapshot
+type NASVolumeSnapshotMock struct {
+	Key    int    `json:"$key"`
+	Volume string `json:"volume"`
+	Name   string `json:"name"`
+}
+
+// NASShareMock represents a mock CIFS or NFS share
+type NASShareMock struct {
+	Key    string `json:"$key"`
+	Volume string `json:"volume"`
+	Name   string `json:"name"`
+}
+
+// NASVolumeSyncMock represents a mock NAS volume sync job
+type NASVolumeSyncMock struct {
+	Key                   string `json:"$key"`
+	Name                  string `json:"name"`
+	Service               int    `json:"service"`
+	SourceVolumeName      string `json:"source_volume_name"`
+	DestinationVolumeName string `json:"destination_volume_name"`
+	Enabled               bool   `json:"enabled"`
+	Status                string `json:"status"`
+	LastRun               int64  `json:"last_run,omitempty"`
+}
+
+// TagMock represents a mock tag
+type TagMock struct {
+	Key  int    `json:"$key"`
+	Name string `json:"name"`
+}
+
+// TagMemberMock represents a mock tag membership. Member is "<table>/<key>".
+type TagMemberMock struct {
+	Key    int    `json:"$key"`
+	Tag    int    `json:"tag"`
+	Member string `json:"member"`
 }
 
 // VMDriveMock represents a mock VM drive
@@ -414,6 +522,78 @@ type VNetMock struct {
 	Type           string `json:"type"`
 	Layer2Type     string `json:"layer2_type"`
 	MonitorGateway bool   `json:"monitor_gateway"`
+	DHCPEnabled    bool   `json:"dhcp_enabled,omitempty"`
+	DHCPStart      string `json:"dhcp_start,omitempty"`
+	DHCPStop       string `json:"dhcp_stop,omitempty"`
+	DNS            string `json:"dns,omitempty"`
+}
+
+// VNetAddressMock represents a mock VNet IP address, such as a DHCP lease
+type VNetAddressMock struct {
+	Key  int    `json:"$key"`
+	VNet int    `json:"vnet"`
+	Type string `json:"type"`
+	IP   string `json:"ip"`
+}
+
+// VNetRuleMock represents a mock VNet firewall rule
+type VNetRuleMock struct {
+	Key       int    `json:"$key"`
+	VNet      int    `json:"vnet"`
+	Name      string `json:"name"`
+	Direction string `json:"direction"`
+	Enabled   bool   `json:"enabled"`
+	Packets   int64  `json:"packets"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// VNetIPsecConnectionMock represents a mock IPsec connection of a VNet
+type VNetIPsecConnectionMock struct {
+	Key           int    `json:"$key"`
+	VNet          int    `json:"vnet"`
+	Name          string `json:"name"`
+	RemoteGateway string `json:"remote_gateway"`
+	Established   bool   `json:"established"`
+	EstablishedAt int64  `json:"established_at,omitempty"`
+	RxBytes       int64  `json:"rx_bytes"`
+	TxBytes       int64  `json:"tx_bytes"`
+}
+
+// VNetWireGuardMock represents a mock WireGuard interface of a VNet
+type VNetWireGuardMock struct {
+	Key  int    `json:"$key"`
+	VNet int    `json:"vnet"`
+	Name string `json:"name"`
+}
+
+// VNetWireGuardPeerMock represents a mock WireGuard peer
+type VNetWireGuardPeerMock struct {
+	Key           int    `json:"$key"`
+	WireGuard     int    `json:"wireguard"`
+	Name          string `json:"name"`
+	LastHandshake int64  `json:"last_handshake,omitempty"`
+	RxBytes       int64  `json:"rx_bytes"`
+	TxBytes       int64  `json:"tx_bytes"`
+}
+
+// VNetBGPNeighborMock represents a mock BGP neighbor of a VNet's router
+type VNetBGPNeighborMock struct {
+	Key                int    `json:"$key"`
+	VNet               int    `json:"vnet"`
+	Neighbor           string `json:"neighbor"`
+	State              string `json:"state"`
+	Uptime             int64  `json:"uptime,omitempty"`
+	PrefixesReceived   int    `json:"prefixes_received,omitempty"`
+	PrefixesAdvertised int    `json:"prefixes_advertised,omitempty"`
+}
+
+// VNetOSPFNeighborMock represents a mock OSPF neighbor of a VNet's router
+type VNetOSPFNeighborMock struct {
+	Key        int    `json:"$key"`
+	VNet       int    `json:"vnet"`
+	NeighborID string `json:"neighbor_id"`
+	State      string `json:"state"`
+	Uptime     int64  `json:"uptime,omitempty"`
 }
 
 // VNetMonitorStatsMock represents a mock VNet gateway-monitoring stats record
@@ -449,3 +629,129 @@ type UpdateSourcePackageMock struct {
 	Source  int    `json:"source"`
 	Version string `json:"version"`
 }
+
+// MachineSnapshotMock represents a mock VM snapshot
+type MachineSnapshotMock struct {
+	Key         int    `json:"$key"`
+	Machine     int    `json:"machine"`
+	Name        string `json:"name"`
+	Created     int64  `json:"created"`
+	Expires     int64  `json:"expires"`
+	SnapMachine int    `json:"snap_machine,omitempty"`
+}
+
+// MachineGuestInfoMock represents mock guest agent info for a machine
+type MachineGuestInfoMock struct {
+	Key          int                          `json:"$key"`
+	Machine      int                          `json:"machine"`
+	OSName       string                       `json:"os_name"`
+	OSVersion    string                       `json:"os_version"`
+	AgentVersion string                       `json:"agent_version"`
+	Uptime       int64                        `json:"uptime"`
+	IPAddresses  []MachineGuestIPAddressMock  `json:"ip_addresses,omitempty"`
+	Filesystems  []MachineGuestFilesystemMock `json:"filesystems,omitempty"`
+}
+
+// MachineGuestIPAddressMock represents a mock guest network address
+type MachineGuestIPAddressMock struct {
+	Interface string `json:"interface"`
+	Address   string `json:"address"`
+}
+
+// MachineGuestFilesystemMock represents a mock guest filesystem
+type MachineGuestFilesystemMock struct {
+	Mountpoint string `json:"mountpoint"`
+	Type       string `json:"type"`
+	TotalBytes int64  `json:"total_bytes"`
+	FreeBytes  int64  `json:"free_bytes"`
+}
+
+// SnapshotProfileMock represents a mock snapshot profile
+type SnapshotProfileMock struct {
+	Key  int    `json:"$key"`
+	Name string `json:"name"`
+}
+
+// SnapshotProfilePeriodMock represents a mock snapshot profile period
+type SnapshotProfilePeriodMock struct {
+	Key       int    `json:"$key"`
+	Profile   int    `json:"profile"`
+	Name      string `json:"name"`
+	Frequency string `json:"frequency"`
+}
+
+// CloudSnapshotMock represents a mock cloud snapshot
+type CloudSnapshotMock struct {
+	Key     int    `json:"$key"`
+	Name    string `json:"name"`
+	Created int64  `json:"created"`
+	Expires int64  `json:"expires"`
+	Status  string `json:"status"`
+}
+
+// SiteMock represents a mock remote site
+type SiteMock struct {
+	Key  int    `json:"$key"`
+	Name string `json:"name"`
+}
+
+// SiteSyncOutgoingMock represents a mock outgoing site sync
+type SiteSyncOutgoingMock struct {
+	Key           int    `json:"$key"`
+	Name          string `json:"name"`
+	Site          int    `json:"site"`
+	Enabled       bool   `json:"enabled"`
+	Status        string `json:"status"`
+	LastRunStart  int64  `json:"last_run_start"`
+	LastRunFinish int64  `json:"last_run_finish"`
+	LastRunBytes  int64  `json:"last_run_bytes"`
+	Throttle      int64  `json:"throttle"`
+}
+
+// SiteSyncOutgoingQueueMock represents a mock snapshot queued on an outgoing site sync
+type SiteSyncOutgoingQueueMock struct {
+	Key      int `json:"$key"`
+	SiteSync int `json:"site_syncs_outgoing"`
+}
+
+// SiteSyncIncomingMock represents a mock incoming site sync
+type SiteSyncIncomingMock struct {
+	Key           int    `json:"$key"`
+	Name          string `json:"name"`
+	Site          int    `json:"site"`
+	Enabled       bool   `json:"enabled"`
+	Status        string `json:"status"`
+	LastRunStart  int64  `json:"last_run_start"`
+	LastRunFinish int64  `json:"last_run_finish"`
+	LastRunBytes  int64  `json:"last_run_bytes"`
+}
+
+// AlarmMock represents a mock active alarm
+type AlarmMock struct {
+	Key       int    `json:"$key"`
+	Level     string `json:"level"`
+	Type      string `json:"alarm_type"`
+	OwnerType string `json:"owner_type"`
+	OwnerName string `json:"owner_name"`
+}
+
+// LogMock represents a mock system log entry
+type LogMock struct {
+	Key        int    `json:"$key"`
+	Level      string `json:"level"`
+	ObjectType string `json:"object_type"`
+	ObjectName string `json:"object_name"`
+	Text       string `json:"text"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// TaskMock represents a mock task
+type TaskMock struct {
+	Key      int     `json:"$key"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
+	Progress float64 `json:"progress"`
+	Started  int64   `json:"started"`
+	Finished int64   `json:"finished"`
+}
@@ -2,8 +2,10 @@ package tests
 
 import (
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"vergeos-exporter/collectors"
@@ -17,7 +19,8 @@ func TestVMCollector(t *testing.T) {
 	config := DefaultMockConfig()
 
 	vms := []VMMock{
-		{Key: 1, Name: "web-server", Machine: 101, Cluster: 1, IsSnapshot: false, PowerState: true, Enabled: true, CPUCores: 4, RAM: 8192},
+		{Key: 1, Name: "web-server", Machine: 101, Cluster: 1, IsSnapshot: false, PowerState: true, Enabled: true, CPUCores: 4, RAM: 8192,
+			MachineType: "pc-q35-8.0", OSFamily: "linux", HAGroup: "web", BootOrder: "cd", CPUType: "host", UEFI: true, SecureBoot: true, Balloon: true},
 		{Key: 2, Name: "db-server", Machine: 102, Cluster: 1, IsSnapshot: false, PowerState: false, Enabled: true, CPUCores: 8, RAM: 16384},
 		{Key: 99, Name: "snap-vm", Machine: 199, Cluster: 1, IsSnapshot: true, PowerState: false, Enabled: false, CPUCores: 2, RAM: 4096},
 	}
@@ -41,9 +44,28 @@ func TestVMCollector(t *testing.T) {
 	}
 
 	allDrives := []VMDriveMock{
-		{Key: 10, Machine: 101, Name: "drive0", Interface: "virtio-scsi", Media: "disk", SizeBytes: 107374182400, UsedBytes: 53687091200, Enabled: true},
-		{Key: 11, Machine: 101, Name: "drive1", Interface: "virtio-scsi", Media: "disk", SizeBytes: 214748364800, UsedBytes: 10737418240, Enabled: true},
-		{Key: 12, Machine: 102, Name: "drive0", Interface: "virtio-scsi", Media: "disk", SizeBytes: 53687091200, UsedBytes: 21474836480, Enabled: true},
+		{Key: 10, Machine: 101, Name: "drive0", Interface: "virtio-scsi", Media: "disk", SizeBytes: 107374182400, UsedBytes: 53687091200, PreferredTier: "1", Enabled: true},
+		{Key: 11, Machine: 101, Name: "drive1", Interface: "virtio-scsi", Media: "disk", SizeBytes: 214748364800, UsedBytes: 10737418240, PreferredTier: "3", Enabled: true},
+		{Key: 12, Machine: 102, Name: "drive0", Interface: "virtio-scsi", Media: "disk", SizeBytes: 53687091200, UsedBytes: 21474836480, PreferredTier: "1", Enabled: true},
+		// Drive of web-server's snapshot, held on machine 150
+		{Key: 20, Machine: 150, Name: "drive0", Interface: "virtio-scsi", Media: "disk", SizeBytes: 107374182400, UsedBytes: 1073741824, PreferredTier: "1", Enabled: true},
+	}
+
+	allSnapshots := []MachineSnapshotMock{
+		{Key: 1, Machine: 101, Name: "hourly-1", SnapMachine: 150},
+	}
+
+	// Only web-server runs the guest agent
+	allGuestInfo := []MachineGuestInfoMock{
+		{
+			Key: 1, Machine: 101, OSName: "Ubuntu", OSVersion: "22.04", AgentVersion: "6.2.0", Uptime: 86400,
+			// The agent repeats an address and stacks two ext4 filesystems on /
+			IPAddresses: []MachineGuestIPAddressMock{{Interface: "eth0", Address: "10.0.0.5"}, {Interface: "eth0", Address: "10.0.0.5"}},
+			Filesystems: []MachineGuestFilesystemMock{
+				{Mountpoint: "/", Type: "ext4", TotalBytes: 53687091200, FreeBytes: 21474836480},
+				{Mountpoint: "/", Type: "ext4", TotalBytes: 10737418240, FreeBytes: 5368709120},
+			},
+		},
 	}
 
 	allDriveStats := []MachineDriveStatsMock{
@@ -73,6 +95,14 @@ func TestVMCollector(t *testing.T) {
 			WriteJSONResponse(w, allDriveStats)
 			return true
 
+		case strings.Contains(r.URL.Path, "/machine_snapshots"):
+			WriteJSONResponse(w, allSnapshots)
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_guest_info"):
+			WriteJSONResponse(w, allGuestInfo)
+			return true
+
 		case strings.Contains(r.URL.Path, "/machine_drives"):
 			WriteJSONResponse(w, allDrives)
 			return true
@@ -128,6 +158,7 @@ func TestVMCollector(t *testing.T) {
 		"vergeos_vm_disk_write_bytes_total": false,
 		"vergeos_vm_disk_util":              false,
 		"vergeos_vm_disk_service_time":      false,
+		"vergeos_vm_storage_used_bytes":     false,
 	}
 
 	for _, mf := range metrics {
@@ -265,22 +296,76 @@ func TestVMCollector(t *testing.T) {
 		expected := `
 			# HELP vergeos_vm_disk_size_bytes Configured disk size in bytes
 			# TYPE vergeos_vm_disk_size_bytes gauge
-			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 1.073741824e+11
-			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 2.147483648e+11
-			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",vm_id="2",vm_name="db-server"} 5.36870912e+10
+			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="1",vm_id="1",vm_name="web-server"} 1.073741824e+11
+			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="3",vm_id="1",vm_name="web-server"} 2.147483648e+11
+			vergeos_vm_disk_size_bytes{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",tier="1",vm_id="2",vm_name="db-server"} 5.36870912e+10
 		`
 		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_disk_size_bytes"); err != nil {
 			t.Errorf("Unexpected metric values: %v", err)
 		}
 	})
 
+	t.Run("storage_used_bytes", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vm_storage_used_bytes Used bytes of the VM's disks (source=disks) or of its snapshots' disks (source=snapshots) on the storage tier
+			# TYPE vergeos_vm_storage_used_bytes gauge
+			vergeos_vm_storage_used_bytes{cluster="compute-cluster",source="disks",system_name="testcloud",tier="1",vm_id="1",vm_name="web-server"} 5.36870912e+10
+			vergeos_vm_storage_used_bytes{cluster="compute-cluster",source="disks",system_name="testcloud",tier="3",vm_id="1",vm_name="web-server"} 1.073741824e+10
+			vergeos_vm_storage_used_bytes{cluster="compute-cluster",source="snapshots",system_name="testcloud",tier="1",vm_id="1",vm_name="web-server"} 1.073741824e+09
+			vergeos_vm_storage_used_bytes{cluster="compute-cluster",source="disks",system_name="testcloud",tier="1",vm_id="2",vm_name="db-server"} 2.147483648e+10
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_storage_used_bytes"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("vm_info", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vm_info VM configuration (always 1)
+			# TYPE vergeos_vm_info gauge
+			vergeos_vm_info{ballooning="true",boot_order="cd",cluster="compute-cluster",cpu_type="host",ha_group="web",machine_type="pc-q35-8.0",os_family="linux",secure_boot="true",system_name="testcloud",uefi="true",vm_id="1",vm_name="web-server"} 1
+			vergeos_vm_info{ballooning="false",boot_order="",cluster="compute-cluster",cpu_type="",ha_group="",machine_type="",os_family="",secure_boot="false",system_name="testcloud",uefi="false",vm_id="2",vm_name="db-server"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_info"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("guest_agent", func(t *testing.T) {
+		// db-server has no agent, so has no guest series
+		expected := `
+			# HELP vergeos_vm_guest_filesystem_free_bytes Guest filesystem free space in bytes reported by the VM's guest agent
+			# TYPE vergeos_vm_guest_filesystem_free_bytes gauge
+			vergeos_vm_guest_filesystem_free_bytes{cluster="compute-cluster",fs_index="0",fs_type="ext4",mountpoint="/",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 2.147483648e+10
+			vergeos_vm_guest_filesystem_free_bytes{cluster="compute-cluster",fs_index="1",fs_type="ext4",mountpoint="/",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 5.36870912e+09
+			# HELP vergeos_vm_guest_filesystem_size_bytes Guest filesystem size in bytes reported by the VM's guest agent
+			# TYPE vergeos_vm_guest_filesystem_size_bytes gauge
+			vergeos_vm_guest_filesystem_size_bytes{cluster="compute-cluster",fs_index="0",fs_type="ext4",mountpoint="/",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 5.36870912e+10
+			vergeos_vm_guest_filesystem_size_bytes{cluster="compute-cluster",fs_index="1",fs_type="ext4",mountpoint="/",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 1.073741824e+10
+			# HELP vergeos_vm_guest_info Guest OS reported by the VM's guest agent (always 1)
+			# TYPE vergeos_vm_guest_info gauge
+			vergeos_vm_guest_info{agent_version="6.2.0",cluster="compute-cluster",node="node1",os_name="Ubuntu",os_version="22.04",system_name="testcloud",vm_id="1",vm_name="web-server"} 1
+			# HELP vergeos_vm_guest_ip_address_info IP address reported by the VM's guest agent (always 1)
+			# TYPE vergeos_vm_guest_ip_address_info gauge
+			vergeos_vm_guest_ip_address_info{address="10.0.0.5",cluster="compute-cluster",interface="eth0",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 1
+			# HELP vergeos_vm_guest_uptime_seconds Guest OS uptime reported by the VM's guest agent
+			# TYPE vergeos_vm_guest_uptime_seconds gauge
+			vergeos_vm_guest_uptime_seconds{cluster="compute-cluster",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 86400
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_vm_guest_info", "vergeos_vm_guest_uptime_seconds", "vergeos_vm_guest_ip_address_info",
+			"vergeos_vm_guest_filesystem_size_bytes", "vergeos_vm_guest_filesystem_free_bytes"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
 	t.Run("disk_read_ops", func(t *testing.T) {
 		expected := `
 			# HELP vergeos_vm_disk_read_ops_total Total disk read operations
 			# TYPE vergeos_vm_disk_read_ops_total counter
-			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 100000
-			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 5000
-			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",vm_id="2",vm_name="db-server"} 200
+			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="1",vm_id="1",vm_name="web-server"} 100000
+			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="3",vm_id="1",vm_name="web-server"} 5000
+			vergeos_vm_disk_read_ops_total{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",tier="1",vm_id="2",vm_name="db-server"} 200
 		`
 		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_disk_read_ops_total"); err != nil {
 			t.Errorf("Unexpected metric values: %v", err)
@@ -291,9 +376,9 @@ func TestVMCollector(t *testing.T) {
 		expected := `
 			# HELP vergeos_vm_disk_util Disk I/O utilization percentage
 			# TYPE vergeos_vm_disk_util gauge
-			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 15.2
-			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",vm_id="1",vm_name="web-server"} 3.1
-			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",vm_id="2",vm_name="db-server"} 1
+			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="1",vm_id="1",vm_name="web-server"} 15.2
+			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive1",interface="virtio-scsi",media="disk",node="node1",system_name="testcloud",tier="3",vm_id="1",vm_name="web-server"} 3.1
+			vergeos_vm_disk_util{cluster="compute-cluster",disk_name="drive0",interface="virtio-scsi",media="disk",node="",system_name="testcloud",tier="1",vm_id="2",vm_name="db-server"} 1
 		`
 		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_disk_util"); err != nil {
 			t.Errorf("Unexpected metric values: %v", err)
@@ -377,6 +462,141 @@ func TestVMCollector_SnapshotFiltering(t *testing.T) {
 	})
 }
 
+func TestVMCollector_NameFilter(t *testing.T) {
+	config := DefaultMockConfig()
+
+	vms := []VMMock{
+		{Key: 1, Name: "prod-web", Machine: 101, Cluster: 1, PowerState: true, Enabled: true, CPUCores: 2, RAM: 4096},
+		{Key: 2, Name: "prod-scratch", Machine: 102, Cluster: 1, PowerState: true, Enabled: true, CPUCores: 2, RAM: 4096},
+		{Key: 3, Name: "dev-web", Machine: 103, Cluster: 1, PowerState: true, Enabled: true, CPUCores: 2, RAM: 4096},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/vms"):
+			WriteJSONResponse(w, vms)
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drive_stats"):
+			WriteJSONResponse(w, []MachineDriveStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drives"):
+			WriteJSONResponse(w, []VMDriveMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_stats"):
+			WriteJSONResponse(w, []MachineStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_status"):
+			WriteJSONResponse(w, []MachineStatusMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_nics"):
+			WriteJSONResponse(w, []MachineNICMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}})
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewVMCollector(client, TestScrapeTimeout, collectors.WithNameFilter(collectors.NameFilter{
+		Include: regexp.MustCompile(`^prod-`),
+		Exclude: regexp.MustCompile(`scratch`),
+	}))
+
+	expected := `
+		# HELP vergeos_vm_cpu_cores Number of configured CPU cores
+		# TYPE vergeos_vm_cpu_cores gauge
+		vergeos_vm_cpu_cores{cluster="cluster1",node="",system_name="testcloud",vm_id="1",vm_name="prod-web"} 2
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_cpu_cores"); err != nil {
+		t.Errorf("Unexpected metric values: %v", err)
+	}
+}
+
+func TestVMCollector_InfoLabels(t *testing.T) {
+	config := DefaultMockConfig()
+
+	vms := []VMMock{
+		{Key: 1, Name: "web", Machine: 101, Cluster: 1, PowerState: true, Enabled: true, OSFamily: "linux", Description: "Public web tier"},
+		{Key: 2, Name: "db", Machine: 102, Cluster: 1, PowerState: true, Enabled: true, OSFamily: "windows"},
+	}
+	tags := []TagMock{
+		{Key: 1, Name: "prod"},
+		{Key: 2, Name: "frontend"},
+	}
+	tagMembers := []TagMemberMock{
+		{Key: 1, Tag: 1, Member: "vms/1"},
+		{Key: 2, Tag: 2, Member: "vms/1"},
+		{Key: 3, Tag: 1, Member: "vms/2"},
+		{Key: 4, Tag: 2, Member: "vnets/1"},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/vms"):
+			WriteJSONResponse(w, vms)
+			return true
+
+		case strings.Contains(r.URL.Path, "/tag_members"):
+			WriteJSONResponse(w, tagMembers)
+			return true
+
+		case strings.Contains(r.URL.Path, "/tags"):
+			WriteJSONResponse(w, tags)
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drive_stats"):
+			WriteJSONResponse(w, []MachineDriveStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drives"):
+			WriteJSONResponse(w, []VMDriveMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_stats"):
+			WriteJSONResponse(w, []MachineStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_status"):
+			WriteJSONResponse(w, []MachineStatusMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_nics"):
+			WriteJSONResponse(w, []MachineNICMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}})
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewVMCollector(client, TestScrapeTimeout,
+		collectors.WithInfoLabels([]string{"os_family", "description", "tags"}))
+
+	// Tags are sorted by name, and tags on other objects are ignored.
+	expected := `
+		# HELP vergeos_vm_info VM configuration (always 1)
+		# TYPE vergeos_vm_info gauge
+		vergeos_vm_info{cluster="cluster1",description="Public web tier",os_family="linux",system_name="testcloud",tags="frontend,prod",vm_id="1",vm_name="web"} 1
+		vergeos_vm_info{cluster="cluster1",description="",os_family="windows",system_name="testcloud",tags="prod",vm_id="2",vm_name="db"} 1
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vm_info"); err != nil {
+		t.Errorf("Unexpected metric values: %v", err)
+	}
+}
+
 func TestVMCollector_StaleMetrics(t *testing.T) {
 	config := DefaultMockConfig()
 	vmCount := 2
@@ -476,3 +696,89 @@ func TestVMCollector_StaleMetrics(t *testing.T) {
 		t.Errorf("Second scrape: expected 1 cpu_total metric (no stale), got %d", count)
 	}
 }
+
+func TestVMCollector_PlacementHistory(t *testing.T) {
+	config := DefaultMockConfig()
+
+	vms := []VMMock{
+		{Key: 1, Name: "web-server", Machine: 101, Cluster: 1, PowerState: true, Enabled: true},
+		{Key: 2, Name: "db-server", Machine: 102, Cluster: 1, PowerState: true, Enabled: true},
+	}
+
+	// Each scrape serves the next placement: web-server live-migrates from
+	// node1 to node2, while db-server goes down with node1 and is restarted
+	// on node3.
+	scrapes := [][]MachineStatusMock{
+		{
+			{Key: 1, Machine: 101, Running: true, Status: "running", NodeName: "node1"},
+			{Key: 2, Machine: 102, Running: true, Status: "running", NodeName: "node1"},
+		},
+		{
+			{Key: 1, Machine: 101, Running: true, Status: "running", NodeName: "node2"},
+			{Key: 2, Machine: 102, Running: false, Status: "stopped"},
+		},
+		{
+			{Key: 1, Machine: 101, Running: true, Status: "running", NodeName: "node2"},
+			{Key: 2, Machine: 102, Running: true, Status: "running", NodeName: "node3"},
+		},
+	}
+	var scrape atomic.Int32
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/vms"):
+			WriteJSONResponse(w, vms)
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drive_stats"):
+			WriteJSONResponse(w, []MachineDriveStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_drives"):
+			WriteJSONResponse(w, []VMDriveMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_stats"):
+			WriteJSONResponse(w, []MachineStatsMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_status"):
+			WriteJSONResponse(w, scrapes[scrape.Add(1)-1])
+			return true
+
+		case strings.Contains(r.URL.Path, "/machine_nics"):
+			WriteJSONResponse(w, []MachineNICMock{})
+			return true
+
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}})
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewVMCollector(client, TestScrapeTimeout)
+
+	testutil.CollectAndCount(collector)
+	testutil.CollectAndCount(collector)
+
+	expected := `
+		# HELP vergeos_vm_migrations_total Times the VM moved node while running (live migrations)
+		# TYPE vergeos_vm_migrations_total counter
+		vergeos_vm_migrations_total{cluster="cluster1",system_name="testcloud",vm_id="1",vm_name="web-server"} 1
+		vergeos_vm_migrations_total{cluster="cluster1",system_name="testcloud",vm_id="2",vm_name="db-server"} 0
+		# HELP vergeos_vm_node_changes_total Times the VM was seen on a different node than before, including restarts elsewhere
+		# TYPE vergeos_vm_node_changes_total counter
+		vergeos_vm_node_changes_total{cluster="cluster1",system_name="testcloud",vm_id="1",vm_name="web-server"} 1
+		vergeos_vm_node_changes_total{cluster="cluster1",system_name="testcloud",vm_id="2",vm_name="db-server"} 1
+		# HELP vergeos_vm_node_migrations_total Live migrations from the source node to the destination node
+		# TYPE vergeos_vm_node_migrations_total counter
+		vergeos_vm_node_migrations_total{destination_node="node2",source_node="node1",system_name="testcloud"} 1
+	`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"vergeos_vm_migrations_total", "vergeos_vm_node_changes_total", "vergeos_vm_node_migrations_total"); err != nil {
+		t.Errorf("Unexpected metric values: %v", err)
+	}
+}
@@ -248,6 +248,109 @@ func TestVNetCollector_NICFetchFailure(t *testing.T) {
 	}
 }
 
+func TestVNetCollector_RouterServices(t *testing.T) {
+	config := DefaultMockConfig()
+	config.CloudName = "test-cloud"
+
+	clusters := []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}}
+	vnets := []VNetMock{
+		{Key: 10, Name: "office", Enabled: true, Running: true, Cluster: 1, Type: "internal", Layer2Type: "vxlan",
+			DHCPEnabled: true, DHCPStart: "192.168.0.100", DHCPStop: "192.168.0.103", DNS: "simple"},
+		{Key: 11, Name: "dmz", Enabled: true, Running: true, Cluster: 1, Type: "internal", Layer2Type: "vxlan",
+			DNS: "disabled"},
+	}
+	// The address handler only returns leases when asked for dynamic ones.
+	addresses := []VNetAddressMock{
+		{Key: 1, VNet: 10, Type: "dynamic", IP: "192.168.0.100"},
+		{Key: 2, VNet: 10, Type: "dynamic", IP: "192.168.0.101"},
+		{Key: 3, VNet: 10, Type: "dynamic", IP: "192.168.0.102"},
+		{Key: 4, VNet: 10, Type: "static", IP: "192.168.0.10"},
+	}
+	rules := []VNetRuleMock{
+		{Key: 1, VNet: 10, Name: "allow-dns", Direction: "incoming", Enabled: true, Packets: 1200, Bytes: 96000},
+		{Key: 2, VNet: 10, Name: "allow-dns", Direction: "incoming", Enabled: true, Packets: 300, Bytes: 24000},
+		{Key: 3, VNet: 11, Name: "legacy-ftp", Direction: "outgoing", Enabled: true},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, clusters)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_addresses"):
+			matched := []VNetAddressMock{}
+			for _, a := range addresses {
+				if strings.Contains(r.URL.Query().Get("filter"), "'"+a.Type+"'") {
+					matched = append(matched, a)
+				}
+			}
+			WriteJSONResponse(w, matched)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_rules"):
+			WriteJSONResponse(w, rules)
+			return true
+		case strings.Contains(r.URL.Path, "/vnets"):
+			WriteJSONResponse(w, vnets)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewVNetCollector(client, TestScrapeTimeout)
+
+	t.Run("dhcp", func(t *testing.T) {
+		// Pool size and leases only for the network serving DHCP
+		expected := `
+			# HELP vergeos_vnet_dhcp_enabled Whether the virtual network's router serves DHCP (1=enabled, 0=disabled)
+			# TYPE vergeos_vnet_dhcp_enabled gauge
+			vergeos_vnet_dhcp_enabled{cluster="cluster1",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 1
+			vergeos_vnet_dhcp_enabled{cluster="cluster1",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="11",vnet_name="dmz"} 0
+			# HELP vergeos_vnet_dhcp_leases Number of active dynamic DHCP leases on the virtual network
+			# TYPE vergeos_vnet_dhcp_leases gauge
+			vergeos_vnet_dhcp_leases{cluster="cluster1",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 3
+			# HELP vergeos_vnet_dhcp_pool_size Number of addresses in the virtual network's dynamic DHCP range
+			# TYPE vergeos_vnet_dhcp_pool_size gauge
+			vergeos_vnet_dhcp_pool_size{cluster="cluster1",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 4
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_vnet_dhcp_enabled", "vergeos_vnet_dhcp_pool_size", "vergeos_vnet_dhcp_leases"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("dns", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vnet_dns_enabled Whether the virtual network's router serves DNS (1=enabled, 0=disabled), by DNS mode
+			# TYPE vergeos_vnet_dns_enabled gauge
+			vergeos_vnet_dns_enabled{cluster="cluster1",dns_mode="simple",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 1
+			vergeos_vnet_dns_enabled{cluster="cluster1",dns_mode="disabled",layer2_type="vxlan",system_name="test-cloud",type="internal",vnet_id="11",vnet_name="dmz"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vnet_dns_enabled"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("firewall_rules", func(t *testing.T) {
+		// Rules sharing a name and direction are summed
+		expected := `
+			# HELP vergeos_vnet_firewall_rule_packets_total Total packets matched by the firewall rule
+			# TYPE vergeos_vnet_firewall_rule_packets_total counter
+			vergeos_vnet_firewall_rule_packets_total{cluster="cluster1",direction="incoming",layer2_type="vxlan",rule_name="allow-dns",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 1500
+			vergeos_vnet_firewall_rule_packets_total{cluster="cluster1",direction="outgoing",layer2_type="vxlan",rule_name="legacy-ftp",system_name="test-cloud",type="internal",vnet_id="11",vnet_name="dmz"} 0
+			# HELP vergeos_vnet_firewall_rule_bytes_total Total bytes matched by the firewall rule
+			# TYPE vergeos_vnet_firewall_rule_bytes_total counter
+			vergeos_vnet_firewall_rule_bytes_total{cluster="cluster1",direction="incoming",layer2_type="vxlan",rule_name="allow-dns",system_name="test-cloud",type="internal",vnet_id="10",vnet_name="office"} 120000
+			vergeos_vnet_firewall_rule_bytes_total{cluster="cluster1",direction="outgoing",layer2_type="vxlan",rule_name="legacy-ftp",system_name="test-cloud",type="internal",vnet_id="11",vnet_name="dmz"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_vnet_firewall_rule_packets_total", "vergeos_vnet_firewall_rule_bytes_total"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+}
+
 func TestVNetCollector_Describe(t *testing.T) {
 	config := DefaultMockConfig()
 
@@ -257,7 +360,8 @@ func TestVNetCollector_Describe(t *testing.T) {
 	client := CreateTestSDKClient(t, mockServer.URL)
 	collector := collectors.NewVNetCollector(client, TestScrapeTimeout)
 
-	ch := make(chan *prometheus.Desc, 20)
+	// 25 vnet descriptors plus the 2 scrape descriptors
+	ch := make(chan *prometheus.Desc, 27)
 	collector.Describe(ch)
 	close(ch)
 
@@ -266,7 +370,7 @@ func TestVNetCollector_Describe(t *testing.T) {
 		count++
 	}
 
-	if count != 18 {
-		t.Errorf("Expected 18 descriptors, got %d", count)
+	if count != 27 {
+		t.Errorf("Expected 27 descriptors, got %d", count)
 	}
 }
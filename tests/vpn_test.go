@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"vergeos-exporter/collectors"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestVPNCollector(t *testing.T) {
+	config := DefaultMockConfig()
+
+	now := time.Now().Unix()
+	clusters := []ClusterMock{{Key: 1, Name: "cluster1", Enabled: true}}
+	vnets := []VNetMock{
+		{Key: 20, Name: "branch-vpn", Enabled: true, Running: true, Cluster: 1, Type: "vpn"},
+		{Key: 21, Name: "remote-access", Enabled: true, Running: true, Cluster: 1, Type: "internal", Layer2Type: "vxlan"},
+	}
+	conns := []VNetIPsecConnectionMock{
+		{Key: 1, VNet: 20, Name: "to-branch1", RemoteGateway: "203.0.113.10", Established: true, EstablishedAt: now - 600, RxBytes: 1000, TxBytes: 2000},
+		{Key: 2, VNet: 20, Name: "to-branch2", RemoteGateway: "203.0.113.20", Established: false},
+	}
+	wireguards := []VNetWireGuardMock{{Key: 1, VNet: 21, Name: "wg0"}}
+	peers := []VNetWireGuardPeerMock{
+		{Key: 1, WireGuard: 1, Name: "laptop-alice", LastHandshake: now - 30, RxBytes: 500, TxBytes: 700},
+		{Key: 2, WireGuard: 1, Name: "laptop-bob", LastHandshake: now - 3600, RxBytes: 100, TxBytes: 50},
+	}
+
+	mockServer := NewBaseMockServer(t, config, func(w http.ResponseWriter, r *http.Request) bool {
+		switch {
+		case strings.Contains(r.URL.Path, "/clusters"):
+			WriteJSONResponse(w, clusters)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_ipsec_connections"):
+			WriteJSONResponse(w, conns)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_wireguard_peers"):
+			WriteJSONResponse(w, peers)
+			return true
+		case strings.Contains(r.URL.Path, "/vnet_wireguards"):
+			WriteJSONResponse(w, wireguards)
+			return true
+		case strings.Contains(r.URL.Path, "/vnets"):
+			WriteJSONResponse(w, vnets)
+			return true
+		}
+		return false
+	})
+	defer mockServer.Close()
+
+	client := CreateTestSDKClient(t, mockServer.URL)
+	collector := collectors.NewVPNCollector(client, TestScrapeTimeout)
+
+	t.Run("tunnel_up", func(t *testing.T) {
+		// bob's last handshake is an hour old, so his peer is down
+		expected := `
+			# HELP vergeos_vpn_tunnel_up Whether the VPN tunnel to the peer is up (1=up, 0=down)
+			# TYPE vergeos_vpn_tunnel_up gauge
+			vergeos_vpn_tunnel_up{cluster="cluster1",layer2_type="",peer="203.0.113.10",protocol="ipsec",system_name="testcloud",tunnel="to-branch1",type="vpn",vnet_id="20",vnet_name="branch-vpn"} 1
+			vergeos_vpn_tunnel_up{cluster="cluster1",layer2_type="",peer="203.0.113.20",protocol="ipsec",system_name="testcloud",tunnel="to-branch2",type="vpn",vnet_id="20",vnet_name="branch-vpn"} 0
+			vergeos_vpn_tunnel_up{cluster="cluster1",layer2_type="vxlan",peer="laptop-alice",protocol="wireguard",system_name="testcloud",tunnel="wg0",type="internal",vnet_id="21",vnet_name="remote-access"} 1
+			vergeos_vpn_tunnel_up{cluster="cluster1",layer2_type="vxlan",peer="laptop-bob",protocol="wireguard",system_name="testcloud",tunnel="wg0",type="internal",vnet_id="21",vnet_name="remote-access"} 0
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vpn_tunnel_up"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("traffic", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vpn_rx_bytes_total Total bytes received through the tunnel
+			# TYPE vergeos_vpn_rx_bytes_total counter
+			vergeos_vpn_rx_bytes_total{cluster="cluster1",layer2_type="",peer="203.0.113.10",protocol="ipsec",system_name="testcloud",tunnel="to-branch1",type="vpn",vnet_id="20",vnet_name="branch-vpn"} 1000
+			vergeos_vpn_rx_bytes_total{cluster="cluster1",layer2_type="",peer="203.0.113.20",protocol="ipsec",system_name="testcloud",tunnel="to-branch2",type="vpn",vnet_id="20",vnet_name="branch-vpn"} 0
+			vergeos_vpn_rx_bytes_total{cluster="cluster1",layer2_type="vxlan",peer="laptop-alice",protocol="wireguard",system_name="testcloud",tunnel="wg0",type="internal",vnet_id="21",vnet_name="remote-access"} 500
+			vergeos_vpn_rx_bytes_total{cluster="cluster1",layer2_type="vxlan",peer="laptop-bob",protocol="wireguard",system_name="testcloud",tunnel="wg0",type="internal",vnet_id="21",vnet_name="remote-access"} 100
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "vergeos_vpn_rx_bytes_total"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+
+	t.Run("handshake_age", func(t *testing.T) {
+		// The never-established IPsec connection has no handshake age
+		if n := testutil.CollectAndCount(collector, "vergeos_vpn_last_handshake_age_seconds"); n != 3 {
+			t.Errorf("Expected 3 handshake age series, got %d", n)
+		}
+	})
+
+	t.Run("totals", func(t *testing.T) {
+		expected := `
+			# HELP vergeos_vpn_tunnels_total Number of VPN tunnels by protocol
+			# TYPE vergeos_vpn_tunnels_total gauge
+			vergeos_vpn_tunnels_total{protocol="ipsec",system_name="testcloud"} 2
+			vergeos_vpn_tunnels_total{protocol="wireguard",system_name="testcloud"} 2
+			# HELP vergeos_vpn_tunnels_up Number of VPN tunnels that are up by protocol
+			# TYPE vergeos_vpn_tunnels_up gauge
+			vergeos_vpn_tunnels_up{protocol="ipsec",system_name="testcloud"} 1
+			vergeos_vpn_tunnels_up{protocol="wireguard",system_name="testcloud"} 1
+		`
+		if err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+			"vergeos_vpn_tunnels_total", "vergeos_vpn_tunnels_up"); err != nil {
+			t.Errorf("Unexpected metric values: %v", err)
+		}
+	})
+}
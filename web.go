@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+// webConfig is the file loaded from -web.config.file. It uses the Prometheus
+// exporter-toolkit format. http_server_config (HTTP/2 and response headers)
+// is accepted but ignored, so a file written for node_exporter works here
+// too unless it sets TLS options this exporter lacks, such as cipher_suites;
+// those are rejected rather than silently dropped.
+type webConfig struct {
+	TLSServerConfig  *webTLSConfig     `yaml:"tls_server_config"`
+	HTTPServerConfig map[string]any    `yaml:"http_server_config"`
+	BasicAuthUsers   map[string]string `yaml:"basic_auth_users"`
+}
+
+// webTLSConfig enables HTTPS on the listener, and mutual TLS when a client CA
+// is given.
+type webTLSConfig struct {
+	CertFile       string `yaml:"cert_file"`
+	KeyFile        string `yaml:"key_file"`
+	ClientCAFile   string `yaml:"client_ca_file"`
+	ClientAuthType string `yaml:"client_auth_type"`
+	MinVersion     string `yaml:"min_version"`
+}
+
+// clientAuthTypes maps client_auth_type values to their tls policies.
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"NoClientCert":               tls.NoClientCert,
+	"RequestClientCert":          tls.RequestClientCert,
+	"RequireAnyClientCert":       tls.RequireAnyClientCert,
+	"VerifyClientCertIfGiven":    tls.VerifyClientCertIfGiven,
+	"RequireAndVerifyClientCert": tls.RequireAndVerifyClientCert,
+}
+
+// tlsVersions maps min_version values to tls versions.
+var tlsVersions = map[string]uint16{
+	"TLS10": tls.VersionTLS10,
+	"TLS11": tls.VersionTLS11,
+	"TLS12": tls.VersionTLS12,
+	"TLS13": tls.VersionTLS13,
+}
+
+// loadWebConfig reads the web config file at path and checks that its
+// certificates load and its password hashes are bcrypt.
+func loadWebConfig(path string) (*webConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open web config file: %w", err)
+	}
+	defer f.Close()
+
+	cfg := &webConfig{}
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse web config file %s: %w", path, err)
+	}
+	if cfg.HTTPServerConfig != nil {
+		log.Printf("Web config file %s: http_server_config is not supported and is ignored", path)
+	}
+
+	for user, hash := range cfg.BasicAuthUsers {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return nil, fmt.Errorf("invalid web config file %s: basic_auth_users: %s: %w", path, user, err)
+		}
+	}
+	if cfg.TLSServerConfig != nil {
+		if _, err := cfg.TLSServerConfig.tlsConfig(); err != nil {
+			return nil, fmt.Errorf("invalid web config file %s: tls_server_config: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+// tlsConfig builds the listener's TLS configuration. The key pair is re-read
+// on every handshake, so renewed certificates take effect without a restart.
+func (c *webTLSConfig) tlsConfig() (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, fmt.Errorf("cert_file and key_file are required")
+	}
+	if _, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile); err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+			if err != nil {
+				return nil, err
+			}
+			return &cert, nil
+		},
+	}
+	if c.MinVersion != "" {
+		v, ok := tlsVersions[c.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown min_version %q", c.MinVersion)
+		}
+		cfg.MinVersion = v
+	}
+
+	// A client CA without an explicit policy means mutual TLS.
+	authType := c.ClientAuthType
+	if authType == "" && c.ClientCAFile != "" {
+		authType = "RequireAndVerifyClientCert"
+	}
+	if authType != "" {
+		t, ok := clientAuthTypes[authType]
+		if !ok {
+			return nil, fmt.Errorf("unknown client_auth_type %q", authType)
+		}
+		cfg.ClientAuth = t
+	}
+	if c.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client_ca_file %s", c.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+	} else if cfg.ClientAuth == tls.VerifyClientCertIfGiven || cfg.ClientAuth == tls.RequireAndVerifyClientCert {
+		return nil, fmt.Errorf("client_auth_type %s requires client_ca_file", authType)
+	}
+	return cfg, nil
+}
+
+// dummyHash is compared against for unknown users so a failed login takes as
+// long whether or not the user exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("vergeos-exporter"), bcrypt.DefaultCost)
+
+// requireBasicAuth wraps next so that every request must carry one of the
+// configured users' credentials. With no users configured it returns next.
+func (c *webConfig) requireBasicAuth(next http.Handler) http.Handler {
+	if len(c.BasicAuthUsers) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if ok {
+			hash, known := c.BasicAuthUsers[user]
+			if !known {
+				hash = string(dummyHash)
+			}
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil && known {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="VergeOS Exporter"`)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+	})
+}